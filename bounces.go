@@ -0,0 +1,212 @@
+package postmark
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Bounces defines the functionality of the bounce resource
+type Bounces interface {
+	// DeliveryStats returns the number of inactive addresses and a summary of bounces by type
+	// http://developer.postmarkapp.com/developer-api-bounce.html#delivery-stats
+	DeliveryStats(ctx context.Context) (*DeliveryStats, error)
+
+	// List returns the bounces matching the given filter, which may be nil
+	// http://developer.postmarkapp.com/developer-api-bounce.html#bounces
+	List(ctx context.Context, count, offset int, filter *BounceFilter) (*BounceList, error)
+
+	// Get retrieves an individual bounce
+	// http://developer.postmarkapp.com/developer-api-bounce.html#single-bounce
+	Get(ctx context.Context, id int64) (*Bounce, error)
+
+	// Dump returns the raw source of the bounce, or an empty string if it is no longer available
+	// http://developer.postmarkapp.com/developer-api-bounce.html#bounce-dump
+	Dump(ctx context.Context, id int64) (string, error)
+
+	// Activate reactivates the address deactivated by a bounce, allowing it to be sent to again
+	// http://developer.postmarkapp.com/developer-api-bounce.html#activate-bounce
+	Activate(ctx context.Context, id int64) (*BounceActivation, error)
+
+	// Tags returns the tags of all messages that have bounced
+	// http://developer.postmarkapp.com/developer-api-bounce.html#bounced-tags
+	Tags(ctx context.Context) ([]string, error)
+}
+
+type bounces struct {
+	pm *postmark
+}
+
+var _ Bounces = (*bounces)(nil)
+
+// Bounce defines a bounce within Postmark. The same fields are sent in bounce webhooks.
+// http://developer.postmarkapp.com/developer-api-bounce.html#single-bounce
+type Bounce struct {
+	// you can use the ID to make different requests to the Bounce API.
+	ID int64
+	// the classification that Postmark assigned the bounce.
+	Type          string
+	TypeCode      int64
+	Name          string
+	Tag           string
+	MessageID     string
+	ServerID      int64
+	MessageStream string
+	Description   string
+	Details       string
+	// the email address that bounced.
+	Email         string
+	From          string
+	BouncedAt     time.Time
+	DumpAvailable bool
+	// lets you know if this bounce caused the email address to be deactivated.
+	Inactive bool
+	// lets you know if this address can be activated again.
+	CanActivate bool
+	Subject     string
+	// the full content of the bounce, only returned when retrieving a single bounce.
+	Content  string
+	Metadata map[string]string
+}
+
+// DeliveryStats defines a summary of the bounces of a server
+type DeliveryStats struct {
+	InactiveMails int64
+	Bounces       []BounceCount
+}
+
+// BounceCount defines the number of bounces of a given type. The summary of all bounces has an
+// empty Type.
+type BounceCount struct {
+	Type  string
+	Name  string
+	Count int64
+}
+
+func (b *bounces) DeliveryStats(ctx context.Context) (*DeliveryStats, error) {
+	stats := new(DeliveryStats)
+	_, err := b.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   "deliverystats",
+		Target: stats,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// BounceFilter defines the criteria bounces can be listed by. Zero values are ignored.
+type BounceFilter struct {
+	Type          string
+	Inactive      *bool
+	EmailFilter   string
+	Tag           string
+	MessageID     string
+	MessageStream string
+	FromDate      time.Time
+	ToDate        time.Time
+}
+
+func (f *BounceFilter) values(params url.Values) {
+	if f == nil {
+		return
+	}
+	setParam(params, "type", f.Type)
+	if f.Inactive != nil {
+		params.Set("inactive", strconv.FormatBool(*f.Inactive))
+	}
+	setParam(params, "emailFilter", f.EmailFilter)
+	setParam(params, "tag", f.Tag)
+	setParam(params, "messageID", f.MessageID)
+	setParam(params, "messagestream", f.MessageStream)
+	setTimeParam(params, "fromdate", f.FromDate)
+	setTimeParam(params, "todate", f.ToDate)
+}
+
+// BounceList defines a list of bounce entities
+type BounceList struct {
+	TotalCount int64
+	Bounces    []*Bounce
+}
+
+func (b *bounces) List(ctx context.Context, count, offset int, filter *BounceFilter) (*BounceList, error) {
+	params := url.Values{
+		"count":  {strconv.Itoa(count)},
+		"offset": {strconv.Itoa(offset)},
+	}
+	filter.values(params)
+
+	bounceList := new(BounceList)
+	_, err := b.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   "bounces",
+		Params: params,
+		Target: bounceList,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bounceList, nil
+}
+
+func (b *bounces) Get(ctx context.Context, id int64) (*Bounce, error) {
+	bounce := new(Bounce)
+	_, err := b.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("bounces", i64toa(id)),
+		Target: bounce,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bounce, nil
+}
+
+func (b *bounces) Dump(ctx context.Context, id int64) (string, error) {
+	var dump struct{ Body string }
+	_, err := b.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("bounces", i64toa(id), "dump"),
+		Target: &dump,
+	})
+	if err != nil {
+		return "", err
+	}
+	return dump.Body, nil
+}
+
+// BounceActivation defines the response after a bounce is activated
+type BounceActivation struct {
+	Message string
+	Bounce  *Bounce
+}
+
+func (b *bounces) Activate(ctx context.Context, id int64) (*BounceActivation, error) {
+	activation := new(BounceActivation)
+	_, err := b.pm.Exec(ctx, &Request{
+		Method: "PUT",
+		Path:   path.Join("bounces", i64toa(id), "activate"),
+		Target: activation,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return activation, nil
+}
+
+func (b *bounces) Tags(ctx context.Context) ([]string, error) {
+	var tags []string
+	_, err := b.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("bounces", "tags"),
+		Target: &tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
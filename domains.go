@@ -0,0 +1,219 @@
+package postmark
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+
+	"golang.org/x/net/context"
+)
+
+// Domains defines the functionality of the domains resource. All of its calls use the account
+// token.
+type Domains interface {
+	// List returns the domains of the account
+	// http://developer.postmarkapp.com/developer-api-domains.html#list-domains
+	List(ctx context.Context, count, offset int) (*DomainList, error)
+
+	// Get retrieves an individual domain
+	// http://developer.postmarkapp.com/developer-api-domains.html#get-domain
+	Get(ctx context.Context, id int64) (*Domain, error)
+
+	// Create creates a new domain within Postmark
+	// http://developer.postmarkapp.com/developer-api-domains.html#create-domain
+	Create(ctx context.Context, domain *DomainReq) (*Domain, error)
+
+	// Edit modifies an existing domain. The Name of the request is ignored, as the name of a
+	// domain cannot be changed.
+	// http://developer.postmarkapp.com/developer-api-domains.html#edit-domain
+	Edit(ctx context.Context, id int64, domain *DomainReq) (*Domain, error)
+
+	// Delete permanently deletes a domain from Postmark
+	// http://developer.postmarkapp.com/developer-api-domains.html#delete-domain
+	Delete(ctx context.Context, id int64) (*StatusResp, error)
+
+	// VerifyDKIM checks the DKIM record of the domain
+	// http://developer.postmarkapp.com/developer-api-domains.html#verify-dkim
+	VerifyDKIM(ctx context.Context, id int64) (*Domain, error)
+
+	// VerifyReturnPath checks the Return-Path CNAME record of the domain
+	// http://developer.postmarkapp.com/developer-api-domains.html#verify-return-path
+	VerifyReturnPath(ctx context.Context, id int64) (*Domain, error)
+
+	// RotateDKIM creates a new DKIM key for the domain. The new key has to be added to DNS
+	// before it replaces the current one.
+	// http://developer.postmarkapp.com/developer-api-domains.html#rotate-dkim
+	RotateDKIM(ctx context.Context, id int64) (*Domain, error)
+}
+
+type domains struct {
+	pm *postmark
+}
+
+var _ Domains = (*domains)(nil)
+
+// Domain defines the domain entities within postmark. Listing domains only returns their ID, name
+// and verification status.
+type Domain struct {
+	ID   int64
+	Name string
+
+	SPFVerified  bool
+	SPFHost      string
+	SPFTextValue string
+
+	DKIMVerified                  bool
+	WeakDKIM                      bool
+	DKIMHost                      string
+	DKIMTextValue                 string
+	DKIMPendingHost               string
+	DKIMPendingTextValue          string
+	DKIMRevokedHost               string
+	DKIMRevokedTextValue          string
+	SafeToRemoveRevokedKeyFromDNS bool
+	DKIMUpdateStatus              string
+
+	ReturnPathDomain           string
+	ReturnPathDomainVerified   bool
+	ReturnPathDomainCNAMEValue string
+}
+
+// Verified returns whether both the DKIM and Return-Path records of the domain have been verified,
+// which is what Postmark requires for a domain to be fully set up.
+func (d *Domain) Verified() bool {
+	return d.DKIMVerified && d.ReturnPathDomainVerified
+}
+
+// DomainReq defines the fields of a domain that can be set when creating or editing it
+type DomainReq struct {
+	Name             string `json:",omitempty"`
+	ReturnPathDomain string `json:",omitempty"`
+}
+
+// DomainList defines a list of domain entities
+type DomainList struct {
+	TotalCount int64
+	Domains    []*Domain
+}
+
+func (d *domains) List(ctx context.Context, count, offset int) (*DomainList, error) {
+	domainList := new(DomainList)
+	_, err := d.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   "domains",
+		Params: url.Values{
+			"count":  {strconv.Itoa(count)},
+			"offset": {strconv.Itoa(offset)},
+		},
+		Target:      domainList,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domainList, nil
+}
+
+func (d *domains) Get(ctx context.Context, id int64) (*Domain, error) {
+	domain := new(Domain)
+	_, err := d.pm.Exec(ctx, &Request{
+		Method:      "GET",
+		Path:        path.Join("domains", i64toa(id)),
+		Target:      domain,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domain, nil
+}
+
+func (d *domains) Create(ctx context.Context, req *DomainReq) (*Domain, error) {
+	domain := new(Domain)
+	_, err := d.pm.Exec(ctx, &Request{
+		Method:      "POST",
+		Path:        "domains",
+		Payload:     req,
+		Target:      domain,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domain, nil
+}
+
+func (d *domains) Edit(ctx context.Context, id int64, req *DomainReq) (*Domain, error) {
+	// the name of a domain cannot be changed, so it is not part of an edit
+	edit := *req
+	edit.Name = ""
+
+	domain := new(Domain)
+	_, err := d.pm.Exec(ctx, &Request{
+		Method:      "PUT",
+		Path:        path.Join("domains", i64toa(id)),
+		Payload:     &edit,
+		Target:      domain,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domain, nil
+}
+
+func (d *domains) Delete(ctx context.Context, id int64) (*StatusResp, error) {
+	resp := new(StatusResp)
+	_, err := d.pm.Exec(ctx, &Request{
+		Method:      "DELETE",
+		Path:        path.Join("domains", i64toa(id)),
+		Target:      resp,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (d *domains) VerifyDKIM(ctx context.Context, id int64) (*Domain, error) {
+	domain := new(Domain)
+	_, err := d.pm.Exec(ctx, &Request{
+		Method:      "PUT",
+		Path:        path.Join("domains", i64toa(id), "verifyDkim"),
+		Target:      domain,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domain, nil
+}
+
+func (d *domains) VerifyReturnPath(ctx context.Context, id int64) (*Domain, error) {
+	domain := new(Domain)
+	_, err := d.pm.Exec(ctx, &Request{
+		Method:      "PUT",
+		Path:        path.Join("domains", i64toa(id), "verifyReturnPath"),
+		Target:      domain,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domain, nil
+}
+
+func (d *domains) RotateDKIM(ctx context.Context, id int64) (*Domain, error) {
+	domain := new(Domain)
+	_, err := d.pm.Exec(ctx, &Request{
+		Method:      "POST",
+		Path:        path.Join("domains", i64toa(id), "rotatedkim"),
+		Target:      domain,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domain, nil
+}
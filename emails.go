@@ -1,8 +1,10 @@
 package postmark
 
 import (
+	"fmt"
 	"path"
 	"time"
+	"unicode/utf8"
 
 	"golang.org/x/net/context"
 )
@@ -16,8 +18,23 @@ type Emails interface {
 	// EmailWithTemplate sends an email with templated content.
 	// http://developer.postmarkape.com/developer-api-templates.html#email-with-template
 	EmailWithTemplate(ctx context.Context, email *EmailWithTemplate) (*EmailResponse, error)
+
+	// BatchWithTemplate sends many emails with templated content. It splits and reports results
+	// the same way as Batch, so the i-th response always belongs to the i-th email.
+	// http://developer.postmarkapp.com/developer-api-templates.html#send-batch-with-templates
+	BatchWithTemplate(ctx context.Context, emails []*EmailWithTemplate) ([]*EmailResponse, error)
+
+	// Batch sends many emails with custom content. Slices longer than MaxBatchSize are split into
+	// several calls. The responses are in the same order as the given emails, and a failure of an
+	// individual message is reported through its response rather than the returned error. If one
+	// of the calls fails outright, the responses gathered so far are returned along with the error.
+	// http://developer.postmarkapp.com/developer-api-email.html#send-batch-emails
+	Batch(ctx context.Context, emails []*Email) ([]*EmailResponse, error)
 }
 
+// MaxBatchSize is the maximum number of messages Postmark accepts in a single batch call.
+const MaxBatchSize = 500
+
 type emails struct {
 	pm *postmark
 }
@@ -35,7 +52,8 @@ const (
 	LinkTrackTypeTextOnly    LinkTrackType = "TextOnly"
 )
 
-// BaseEmail defines the fields common to all Postmark emails
+// BaseEmail defines the fields common to all Postmark emails, including templated ones. Emails
+// without a MessageStream are sent through the default transactional stream, "outbound".
 type BaseEmail struct {
 	From        string        `json:",omitempty"`
 	To          string        `json:",omitempty"`
@@ -47,6 +65,48 @@ type BaseEmail struct {
 	TrackOpens  *bool         `json:",omitempty"`
 	TrackLinks  LinkTrackType `json:",omitempty"`
 	Attachments []Attachment  `json:",omitempty"`
+
+	MessageStream string `json:",omitempty"`
+
+	// Metadata is returned by message searches and sent along with webhooks about the email,
+	// which allows them to be matched to other records. See ValidateMetadata for its limits.
+	Metadata map[string]string `json:",omitempty"`
+}
+
+// Limits Postmark places on the metadata of an email
+const (
+	MaxMetadataFields      = 10
+	MaxMetadataKeyLength   = 20
+	MaxMetadataValueLength = 80
+)
+
+// ValidateMetadata checks metadata against the limits Postmark places on it. It is called before
+// any email is sent, so that invalid metadata is caught without a round trip.
+func ValidateMetadata(metadata map[string]string) error {
+	if err := validateMetadata(metadata); err != nil {
+		return fmt.Errorf("postmark: %v", err)
+	}
+	return nil
+}
+
+// validateMetadata returns the reason metadata is invalid, without the prefix of the package's
+// errors so that batch calls can add the index of the message
+func validateMetadata(metadata map[string]string) error {
+	if len(metadata) > MaxMetadataFields {
+		return fmt.Errorf("metadata has %d fields, at most %d are allowed", len(metadata), MaxMetadataFields)
+	}
+	for k, v := range metadata {
+		if k == "" {
+			return fmt.Errorf("metadata keys cannot be empty")
+		}
+		if utf8.RuneCountInString(k) > MaxMetadataKeyLength {
+			return fmt.Errorf("metadata key %q is longer than %d characters", k, MaxMetadataKeyLength)
+		}
+		if utf8.RuneCountInString(v) > MaxMetadataValueLength {
+			return fmt.Errorf("metadata value of %q is longer than %d characters", k, MaxMetadataValueLength)
+		}
+	}
+	return nil
 }
 
 // Header defines an email header within the Postmark API
@@ -72,6 +132,15 @@ type EmailResponse struct {
 	Message     string
 }
 
+// Err returns the error described by the response, or nil if the message was accepted. This is
+// mostly useful for batch calls, where each message succeeds or fails independently.
+func (er *EmailResponse) Err() error {
+	if er.ErrorCode == 0 {
+		return nil
+	}
+	return &Error{ErrorCode: er.ErrorCode, Message: er.Message}
+}
+
 // Email defines an email object within the Postmark API
 type Email struct {
 	BaseEmail
@@ -82,6 +151,13 @@ type Email struct {
 }
 
 func (e *emails) Email(ctx context.Context, email *Email) (*EmailResponse, error) {
+	if email == nil {
+		return nil, fmt.Errorf("postmark: email is nil")
+	}
+	if err := ValidateMetadata(email.Metadata); err != nil {
+		return nil, err
+	}
+
 	er := new(EmailResponse)
 	_, err := e.pm.Exec(ctx, &Request{
 		Method:  "POST",
@@ -95,6 +171,38 @@ func (e *emails) Email(ctx context.Context, email *Email) (*EmailResponse, error
 	return er, nil
 }
 
+func (e *emails) Batch(ctx context.Context, emails []*Email) ([]*EmailResponse, error) {
+	for i, email := range emails {
+		if email == nil {
+			return nil, fmt.Errorf("postmark: message %d: email is nil", i)
+		}
+		if err := validateMetadata(email.Metadata); err != nil {
+			return nil, fmt.Errorf("postmark: message %d: %v", i, err)
+		}
+	}
+
+	ers := make([]*EmailResponse, 0, len(emails))
+	err := forEachBatch(len(emails), MaxBatchSize, func(start, end int) error {
+		var chunk []*EmailResponse
+		_, err := e.pm.Exec(ctx, &Request{
+			Method:  "POST",
+			Path:    path.Join("email", "batch"),
+			Payload: emails[start:end],
+			Target:  &chunk,
+		})
+		if err == nil && len(chunk) != end-start {
+			// later responses could not be matched to their emails
+			return fmt.Errorf("postmark: got %d responses for a batch of %d emails", len(chunk), end-start)
+		}
+		ers = append(ers, chunk...)
+		return err
+	})
+	if err != nil {
+		return ers, err
+	}
+	return ers, nil
+}
+
 // EmailWithTemplate defines a templated email to the postmark API
 type EmailWithTemplate struct {
 	BaseEmail
@@ -105,6 +213,13 @@ type EmailWithTemplate struct {
 }
 
 func (e *emails) EmailWithTemplate(ctx context.Context, email *EmailWithTemplate) (*EmailResponse, error) {
+	if email == nil {
+		return nil, fmt.Errorf("postmark: email is nil")
+	}
+	if err := ValidateMetadata(email.Metadata); err != nil {
+		return nil, err
+	}
+
 	er := new(EmailResponse)
 	_, err := e.pm.Exec(ctx, &Request{
 		Method:  "POST",
@@ -117,3 +232,55 @@ func (e *emails) EmailWithTemplate(ctx context.Context, email *EmailWithTemplate
 	}
 	return er, nil
 }
+
+// batchWithTemplate defines the payload of a templated batch call
+type batchWithTemplate struct {
+	Messages []*EmailWithTemplate
+}
+
+func (e *emails) BatchWithTemplate(ctx context.Context, emails []*EmailWithTemplate) ([]*EmailResponse, error) {
+	for i, email := range emails {
+		if email == nil {
+			return nil, fmt.Errorf("postmark: message %d: email is nil", i)
+		}
+		if err := validateMetadata(email.Metadata); err != nil {
+			return nil, fmt.Errorf("postmark: message %d: %v", i, err)
+		}
+	}
+
+	ers := make([]*EmailResponse, 0, len(emails))
+	err := forEachBatch(len(emails), MaxBatchSize, func(start, end int) error {
+		var chunk []*EmailResponse
+		_, err := e.pm.Exec(ctx, &Request{
+			Method:  "POST",
+			Path:    path.Join("email", "batchWithTemplates"),
+			Payload: &batchWithTemplate{Messages: emails[start:end]},
+			Target:  &chunk,
+		})
+		if err == nil && len(chunk) != end-start {
+			// later responses could not be matched to their emails
+			return fmt.Errorf("postmark: got %d responses for a batch of %d emails", len(chunk), end-start)
+		}
+		ers = append(ers, chunk...)
+		return err
+	})
+	if err != nil {
+		return ers, err
+	}
+	return ers, nil
+}
+
+// forEachBatch calls fn with the bounds of consecutive chunks of at most size out of n items,
+// stopping at the first error.
+func forEachBatch(n, size int, fn func(start, end int) error) error {
+	for start := 0; start < n; start += size {
+		end := start + size
+		if end > n {
+			end = n
+		}
+		if err := fn(start, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
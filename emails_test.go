@@ -0,0 +1,176 @@
+package postmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestValidateMetadata(t *testing.T) {
+	tooMany := map[string]string{}
+	for i := 0; i <= MaxMetadataFields; i++ {
+		tooMany[string(rune('a'+i))] = "value"
+	}
+
+	for _, test := range []struct {
+		name     string
+		metadata map[string]string
+		valid    bool
+	}{
+		{"nil", nil, true},
+		{"valid", map[string]string{"user-id": "1234", "tenant": "acme"}, true},
+		{"too many fields", tooMany, false},
+		{"empty key", map[string]string{"": "value"}, false},
+		{"long key", map[string]string{strings.Repeat("k", MaxMetadataKeyLength+1): "value"}, false},
+		{"long value", map[string]string{"key": strings.Repeat("v", MaxMetadataValueLength+1)}, false},
+		{"multibyte value", map[string]string{"key": strings.Repeat("é", MaxMetadataValueLength)}, true},
+	} {
+		err := ValidateMetadata(test.metadata)
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
+
+func TestBatch(t *testing.T) {
+	var calls, failOn int
+	p := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var emails []*Email
+		if err := json.NewDecoder(r.Body).Decode(&emails); err != nil {
+			t.Errorf("error decoding batch: %v", err)
+		}
+		if len(emails) > MaxBatchSize {
+			t.Errorf("batch of %d emails is too large", len(emails))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if calls == failOn {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"ErrorCode": 300, "Message": "Invalid email request"}`)
+			return
+		}
+		ers := make([]*EmailResponse, len(emails))
+		for i, email := range emails {
+			ers[i] = &EmailResponse{To: email.To, MessageID: email.To}
+		}
+		json.NewEncoder(w).Encode(ers)
+	})
+
+	emails := make([]*Email, 2*MaxBatchSize+1)
+	for i := range emails {
+		emails[i] = &Email{BaseEmail: BaseEmail{To: fmt.Sprintf("%d@example.com", i)}}
+	}
+	checkOrder := func(ers []*EmailResponse) {
+		for i, er := range ers {
+			if expected := fmt.Sprintf("%d@example.com", i); er.To != expected {
+				t.Errorf("response %d is for %q, expected %q", i, er.To, expected)
+				return
+			}
+		}
+	}
+
+	ers, err := p.Emails().Batch(context.Background(), emails)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 || len(ers) != len(emails) {
+		t.Errorf("expected %d responses in 3 calls, got %d in %d", len(emails), len(ers), calls)
+	}
+	checkOrder(ers)
+
+	// the responses of the chunks sent before a failure are returned with the error
+	calls, failOn = 0, 2
+	ers, err = p.Emails().Batch(context.Background(), emails)
+	if pmerr, ok := err.(*Error); !ok || pmerr.ErrorCode != 300 {
+		t.Errorf("expected API error 300, got %v", err)
+	}
+	if calls != 2 || len(ers) != MaxBatchSize {
+		t.Errorf("expected %d responses in 2 calls, got %d in %d", MaxBatchSize, len(ers), calls)
+	}
+	checkOrder(ers)
+
+	emails[3] = nil
+	if _, err := p.Emails().Batch(context.Background(), emails); err == nil || err.Error() != "postmark: message 3: email is nil" {
+		t.Errorf("expected an error for a nil email, got %v", err)
+	}
+}
+
+func TestBatchWithTemplate(t *testing.T) {
+	var calls int
+	var short bool
+	p := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/email/batchWithTemplates" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		var payload struct{ Messages []*EmailWithTemplate }
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("error decoding batch: %v", err)
+		}
+
+		ers := make([]*EmailResponse, len(payload.Messages))
+		for i, email := range payload.Messages {
+			if email.TemplateID != "1234" {
+				t.Errorf("unexpected template: %q", email.TemplateID)
+			}
+			ers[i] = &EmailResponse{To: email.To}
+		}
+		if short && calls == 2 {
+			ers = ers[1:]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ers)
+	})
+
+	emails := make([]*EmailWithTemplate, MaxBatchSize+1)
+	for i := range emails {
+		emails[i] = &EmailWithTemplate{
+			BaseEmail:  BaseEmail{To: fmt.Sprintf("%d@example.com", i)},
+			TemplateID: "1234",
+		}
+	}
+
+	ers, err := p.Templates().EmailBatch(context.Background(), emails)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 || len(ers) != len(emails) {
+		t.Fatalf("expected %d responses in 2 calls, got %d in %d", len(emails), len(ers), calls)
+	}
+	for i, er := range ers {
+		if er.To != emails[i].To {
+			t.Errorf("response %d is for %q, expected %q", i, er.To, emails[i].To)
+			break
+		}
+	}
+
+	// responses that cannot be matched to their emails are not returned
+	calls, short = 0, true
+	ers, err = p.Templates().EmailBatch(context.Background(), emails)
+	if err == nil {
+		t.Errorf("expected an error for a missing response")
+	}
+	if len(ers) != MaxBatchSize {
+		t.Errorf("expected %d responses, got %d", MaxBatchSize, len(ers))
+	}
+}
+
+func TestNilEmail(t *testing.T) {
+	p := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected call to %s", r.URL.Path)
+	})
+
+	if _, err := p.Emails().Email(context.Background(), nil); err == nil {
+		t.Errorf("expected an error for a nil email")
+	}
+	if _, err := p.Emails().EmailWithTemplate(context.Background(), nil); err == nil {
+		t.Errorf("expected an error for a nil email")
+	}
+}
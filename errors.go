@@ -68,3 +68,53 @@ var ErrorLookup = map[int]string{
 	1122: "A Templated field has been submitted that is invalid.",
 	1123: "A field was included in the request body that is not allowed.",
 }
+
+// Errors that can be returned by the API, for use with errors.Is:
+//
+//	if errors.Is(err, postmark.ErrInboundRetryFailed) {
+//		// ...
+//	}
+var (
+	ErrSenderSignatureQuery        = apiError(500)
+	ErrSenderSignatureNotFound     = apiError(501)
+	ErrNoSenderSignatureData       = apiError(502)
+	ErrPublicDomain                = apiError(503)
+	ErrSenderSignatureExists       = apiError(504)
+	ErrDKIMAlreadyRenewing         = apiError(505)
+	ErrSenderSignatureConfirmed    = apiError(506)
+	ErrSenderSignatureNotOwned     = apiError(507)
+	ErrSenderSignatureMissingField = apiError(520)
+	ErrSenderSignatureFieldTooLong = apiError(521)
+	ErrSenderSignatureInvalidField = apiError(522)
+
+	ErrServerNotFound         = apiError(601)
+	ErrDuplicateInboundDomain = apiError(602)
+	ErrServerNameExists       = apiError(603)
+	ErrNoDeleteAccess         = apiError(604)
+	ErrServerDeleteFailed     = apiError(605)
+	ErrInvalidWebhookURL      = apiError(606)
+	ErrInvalidServerColor     = apiError(607)
+	ErrInvalidServerName      = apiError(608)
+	ErrNoServerData           = apiError(609)
+	ErrInvalidInboundMX       = apiError(610)
+	ErrInvalidSpamThreshold   = apiError(611)
+
+	ErrMessageNotFound     = apiError(701)
+	ErrInboundBypassFailed = apiError(702)
+	ErrInboundRetryFailed  = apiError(703)
+
+	ErrTriggerQuery            = apiError(800)
+	ErrTagTriggerNotFound      = apiError(801)
+	ErrTagTriggerExists        = apiError(803)
+	ErrMissingMatchName        = apiError(808)
+	ErrNoTriggerData           = apiError(809)
+	ErrInboundRuleExists       = apiError(810)
+	ErrInboundRuleDeleteFailed = apiError(811)
+	ErrInboundRuleNotFound     = apiError(812)
+	ErrInvalidInboundRule      = apiError(813)
+)
+
+// apiError returns an *Error that matches any error returned by the API with the given code
+func apiError(code int) *Error {
+	return &Error{ErrorCode: code, Message: ErrorLookup[code]}
+}
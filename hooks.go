@@ -0,0 +1,60 @@
+package postmark
+
+import (
+	"net/http"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Hooks observe the calls made by a client, for example to trace them or export metrics. Every
+// field is optional. Hooks are called for every attempt of a call, so a call that is retried
+// triggers them several times.
+type Hooks struct {
+	// BeforeRequest is called right before a request is sent, and may modify it. It may return a
+	// context derived from ctx, for example carrying a tracing span, which is then passed to the
+	// other hooks of the attempt, or nil to keep ctx. The context of r is left unchanged.
+	BeforeRequest func(ctx context.Context, req *Request, r *http.Request) context.Context
+
+	// AfterResponse is called once a response has been read, whether or not its status
+	// indicates success, along with the time since the request was sent.
+	AfterResponse func(ctx context.Context, req *Request, resp *http.Response, elapsed time.Duration)
+
+	// OnError is called when an attempt fails, including when no request could be sent, for
+	// example because ctx was done while waiting for the limits or before a retry. err is an
+	// *Error if the API responded with one, and resp is nil if no response was received.
+	OnError func(ctx context.Context, req *Request, resp *http.Response, err error)
+}
+
+// WithHooks adds hooks to the client. Hooks are called in the order they were added.
+func WithHooks(hooks Hooks) Option {
+	return func(p *postmark) {
+		p.hooks = append(p.hooks, hooks)
+	}
+}
+
+// beforeRequest calls the BeforeRequest hooks and returns the context to pass to the other hooks
+func (p *postmark) beforeRequest(ctx context.Context, req *Request, r *http.Request) context.Context {
+	for _, h := range p.hooks {
+		if h.BeforeRequest != nil {
+			if hookCtx := h.BeforeRequest(ctx, req, r); hookCtx != nil {
+				ctx = hookCtx
+			}
+		}
+	}
+	return ctx
+}
+
+func (p *postmark) afterResponse(ctx context.Context, req *Request, resp *http.Response, elapsed time.Duration, err error) {
+	if terr, ok := err.(*transportError); ok {
+		err = terr.err
+	}
+	for _, h := range p.hooks {
+		if resp != nil && h.AfterResponse != nil {
+			h.AfterResponse(ctx, req, resp, elapsed)
+		}
+		if err != nil && h.OnError != nil {
+			h.OnError(ctx, req, resp, err)
+		}
+	}
+}
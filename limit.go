@@ -0,0 +1,103 @@
+package postmark
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Limiter limits the rate of calls to the API. Wait blocks until a call may be made, or returns an
+// error if ctx is done first. *rate.Limiter from golang.org/x/time/rate satisfies it, as does the
+// Limiter returned by NewRateLimiter.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// NewRateLimiter returns a token bucket Limiter allowing rps calls per second on average, and
+// bursts of up to burst calls. The rate is not limited if rps is not positive.
+func NewRateLimiter(rps float64, burst int) Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil || b.rate <= 0 {
+		return err
+	}
+
+	// take a token right away, going into debt if there is none, so that waiting callers are
+	// served in order
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.tokens--
+	wait := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(wait)) {
+		b.refund()
+		return context.DeadlineExceeded
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		b.refund()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// refund returns the token of a caller that gave up waiting for it
+func (b *tokenBucket) refund() {
+	b.mu.Lock()
+	b.tokens++
+	b.mu.Unlock()
+}
+
+// acquire waits until a call may be made according to the limiter and the maximum number of
+// calls in flight of the client. The returned function has to be called once the call is done.
+func (p *postmark) acquire(ctx context.Context) (func(), error) {
+	if p.limiter != nil {
+		if err := p.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	sem := p.inFlight
+	if sem == nil {
+		return func() {}, nil
+	}
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,79 @@
+package postmark
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestRateLimiter(t *testing.T) {
+	l := NewRateLimiter(100, 2)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// the burst is immediate, the two calls after it take 10ms each
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("expected calls to be limited, took %v", elapsed)
+	}
+
+	// a call that cannot be made before the deadline fails right away
+	ctx, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestMaxInFlight(t *testing.T) {
+	started, release := make(chan struct{}, 5), make(chan struct{})
+	var inFlight, maxInFlight int32
+	p := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		started <- struct{}{}
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	})
+	p.SetMaxInFlight(2)
+
+	done := make(chan error)
+	for i := 0; i < 5; i++ {
+		go func() {
+			_, err := p.Templates().Get(context.Background(), 1)
+			done <- err
+		}()
+	}
+
+	<-started
+	<-started
+	select {
+	case <-started:
+		t.Errorf("a third call started while 2 were in flight")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	for i := 0; i < 5; i++ {
+		if err := <-done; err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+
+	if maxInFlight != 2 {
+		t.Errorf("expected at most 2 calls in flight, got %d", maxInFlight)
+	}
+}
@@ -0,0 +1,159 @@
+package postmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// LogLevel defines the severity of a message sent to a Logger.
+type LogLevel int
+
+// LogLevel constant definitions.
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LogLevel(%d)", int(l))
+}
+
+// Logger receives structured diagnostics of a client. API tokens and the content of emails are
+// redacted from every field before it is logged. Clients do not log anything unless a Logger is
+// set with WithLogger.
+type Logger interface {
+	// Enabled reports whether messages of the given level are logged, so that fields which are
+	// expensive to compute are only computed when needed.
+	Enabled(level LogLevel) bool
+
+	// Log logs a message along with fields describing it.
+	Log(level LogLevel, msg string, fields map[string]interface{})
+}
+
+// NewStdLogger returns a Logger writing messages of at least minLevel to the given *log.Logger,
+// or to the standard logger of the log package if it is nil.
+func NewStdLogger(l *log.Logger, minLevel LogLevel) Logger {
+	return &stdLogger{logger: l, minLevel: minLevel}
+}
+
+type stdLogger struct {
+	logger   *log.Logger
+	minLevel LogLevel
+}
+
+func (l *stdLogger) Enabled(level LogLevel) bool {
+	return level >= l.minLevel
+}
+
+func (l *stdLogger) Log(level LogLevel, msg string, fields map[string]interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	line := level.String() + " " + msg
+	for _, k := range keys {
+		line += fmt.Sprintf(" %s=%v", k, fields[k])
+	}
+
+	if l.logger != nil {
+		l.logger.Print(line)
+	} else {
+		log.Print(line)
+	}
+}
+
+// nopLogger is the Logger of clients without one
+type nopLogger struct{}
+
+func (nopLogger) Enabled(LogLevel) bool                        { return false }
+func (nopLogger) Log(LogLevel, string, map[string]interface{}) {}
+
+const redacted = "[REDACTED]"
+
+// redactedHeaders lists the headers carrying credentials
+var redactedHeaders = []string{serverTokenHeader, accountTokenHeader, "Authorization", "Cookie"}
+
+// redactHeaders returns a copy of h without credentials
+func redactHeaders(h http.Header) http.Header {
+	clean := make(http.Header, len(h))
+	for k, v := range h {
+		clean[k] = v
+	}
+	for _, k := range redactedHeaders {
+		if _, ok := clean[http.CanonicalHeaderKey(k)]; ok {
+			clean.Set(k, redacted)
+		}
+	}
+	return clean
+}
+
+// redactedFields lists the JSON fields of payloads holding the content of emails or credentials
+var redactedFields = map[string]bool{
+	"HtmlBody":      true,
+	"TextBody":      true,
+	"Content":       true,
+	"TemplateModel": true,
+	"Password":      true,
+}
+
+// redactPayload returns the JSON encoding of a payload without the content of emails or
+// credentials
+func redactPayload(data []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return redacted
+	}
+	redactValue(v)
+	clean, err := json.Marshal(v)
+	if err != nil {
+		return redacted
+	}
+	return string(clean)
+}
+
+func redactValue(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if redactedFields[k] {
+				v[k] = redacted
+			} else {
+				redactValue(field)
+			}
+		}
+	case []interface{}:
+		for _, elem := range v {
+			redactValue(elem)
+		}
+	}
+}
+
+// truncate shortens s for logging
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.ToValidUTF8(s[:n], "") + "..."
+}
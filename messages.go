@@ -0,0 +1,473 @@
+package postmark
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Messages defines the functionality of the messages resource
+type Messages interface {
+	// OutboundSearch returns the sent messages matching the given filter, which may be nil
+	// http://developer.postmarkapp.com/developer-api-messages.html#outbound-message-search
+	OutboundSearch(ctx context.Context, count, offset int, filter *OutboundMessageFilter) (*OutboundMessageList, error)
+
+	// OutboundDetails retrieves an individual sent message, including its delivery events
+	// http://developer.postmarkapp.com/developer-api-messages.html#outbound-message-details
+	OutboundDetails(ctx context.Context, messageID string) (*OutboundMessageDetails, error)
+
+	// OutboundDump returns the raw source of a sent message, or an empty string if it is no
+	// longer available
+	// http://developer.postmarkapp.com/developer-api-messages.html#outbound-message-dump
+	OutboundDump(ctx context.Context, messageID string) (string, error)
+
+	// InboundSearch returns the received messages matching the given filter, which may be nil
+	// http://developer.postmarkapp.com/developer-api-messages.html#inbound-message-search
+	InboundSearch(ctx context.Context, count, offset int, filter *InboundMessageFilter) (*InboundMessageList, error)
+
+	// InboundDetails retrieves an individual received message
+	// http://developer.postmarkapp.com/developer-api-messages.html#inbound-message-details
+	InboundDetails(ctx context.Context, messageID string) (*InboundMessageDetails, error)
+
+	// Bypass delivers an inbound message that was blocked by the spam filter or an inbound rule.
+	// Failures are reported as ErrInboundBypassFailed.
+	// http://developer.postmarkapp.com/developer-api-messages.html#bypass-rules-for-a-blocked-inbound-message
+	Bypass(ctx context.Context, messageID string) (*StatusResp, error)
+
+	// Retry attempts to deliver an inbound message whose webhook failed again. Failures are
+	// reported as ErrInboundRetryFailed.
+	// http://developer.postmarkapp.com/developer-api-messages.html#retry-a-failed-inbound-message-for-processing
+	Retry(ctx context.Context, messageID string) (*StatusResp, error)
+
+	// Opens returns the opens of all sent messages matching the given filter, which may be nil
+	// http://developer.postmarkapp.com/developer-api-messages.html#message-opens
+	Opens(ctx context.Context, count, offset int, filter *TrackingFilter) (*OpenList, error)
+
+	// MessageOpens returns the opens of a single sent message
+	// http://developer.postmarkapp.com/developer-api-messages.html#opens-for-a-single-message
+	MessageOpens(ctx context.Context, messageID string, count, offset int) (*OpenList, error)
+
+	// Clicks returns the link clicks of all sent messages matching the given filter, which may
+	// be nil
+	// http://developer.postmarkapp.com/developer-api-messages.html#message-clicks
+	Clicks(ctx context.Context, count, offset int, filter *TrackingFilter) (*ClickList, error)
+
+	// MessageClicks returns the link clicks of a single sent message
+	// http://developer.postmarkapp.com/developer-api-messages.html#clicks-for-a-single-message
+	MessageClicks(ctx context.Context, messageID string, count, offset int) (*ClickList, error)
+}
+
+type messages struct {
+	pm *postmark
+}
+
+var _ Messages = (*messages)(nil)
+
+// EmailAddress defines a named email address within the Postmark API
+type EmailAddress struct {
+	Email string
+	Name  string
+}
+
+// OutboundMessage defines a sent message as returned by a message search
+type OutboundMessage struct {
+	Tag           string
+	MessageID     string
+	To            []EmailAddress
+	Cc            []EmailAddress
+	Bcc           []EmailAddress
+	Recipients    []string
+	ReceivedAt    time.Time
+	From          string
+	Subject       string
+	Attachments   []string
+	Status        string
+	TrackOpens    bool
+	TrackLinks    LinkTrackType
+	Metadata      map[string]string
+	MessageStream string
+}
+
+// OutboundMessageFilter defines the criteria sent messages can be searched by. Zero values are
+// ignored. Metadata matches messages that were sent with all of the given metadata values.
+type OutboundMessageFilter struct {
+	Recipient string
+	FromEmail string
+	Tag       string
+	Subject   string
+	// one of "queued" or "sent"
+	Status        string
+	MessageStream string
+	FromDate      time.Time
+	ToDate        time.Time
+	Metadata      map[string]string
+}
+
+func (f *OutboundMessageFilter) values(params url.Values) {
+	if f == nil {
+		return
+	}
+	setParam(params, "recipient", f.Recipient)
+	setParam(params, "fromemail", f.FromEmail)
+	setParam(params, "tag", f.Tag)
+	setParam(params, "subject", f.Subject)
+	setParam(params, "status", f.Status)
+	setParam(params, "messagestream", f.MessageStream)
+	setTimeParam(params, "fromdate", f.FromDate)
+	setTimeParam(params, "todate", f.ToDate)
+	for k, v := range f.Metadata {
+		params.Set("metadata_"+k, v)
+	}
+}
+
+// OutboundMessageList defines a list of sent messages
+type OutboundMessageList struct {
+	TotalCount int64
+	Messages   []*OutboundMessage
+}
+
+func (m *messages) OutboundSearch(ctx context.Context, count, offset int, filter *OutboundMessageFilter) (*OutboundMessageList, error) {
+	params := url.Values{
+		"count":  {strconv.Itoa(count)},
+		"offset": {strconv.Itoa(offset)},
+	}
+	filter.values(params)
+
+	msgList := new(OutboundMessageList)
+	_, err := m.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("messages", "outbound"),
+		Params: params,
+		Target: msgList,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return msgList, nil
+}
+
+// OutboundMessageDetails defines a sent message along with its content and history
+type OutboundMessageDetails struct {
+	OutboundMessage
+
+	TextBody      string
+	HTMLBody      string `json:"HtmlBody"`
+	Body          string
+	MessageEvents []MessageEvent
+}
+
+// MessageEvent defines something that happened to a sent message, such as its delivery, a bounce
+// or an open. The contents of Details depend on the Type of the event.
+type MessageEvent struct {
+	Recipient  string
+	Type       string
+	ReceivedAt time.Time
+	Details    map[string]interface{}
+}
+
+func (m *messages) OutboundDetails(ctx context.Context, messageID string) (*OutboundMessageDetails, error) {
+	details := new(OutboundMessageDetails)
+	_, err := m.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("messages", "outbound", messageID, "details"),
+		Target: details,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+func (m *messages) OutboundDump(ctx context.Context, messageID string) (string, error) {
+	var dump struct{ Body string }
+	_, err := m.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("messages", "outbound", messageID, "dump"),
+		Target: &dump,
+	})
+	if err != nil {
+		return "", err
+	}
+	return dump.Body, nil
+}
+
+// InboundMessage defines a received message as returned by a message search
+type InboundMessage struct {
+	From              string
+	FromName          string
+	FromFull          InboundEntity
+	To                string
+	ToFull            []InboundEntity
+	Cc                string
+	CcFull            []InboundEntity
+	ReplyTo           string
+	OriginalRecipient string
+	Subject           string
+	Date              string
+	MailboxHash       string
+	Attachments       []InboundAttachment
+	MessageID         string
+	Tag               string
+	Status            string
+	MessageStream     string
+}
+
+// InboundMessageFilter defines the criteria received messages can be searched by. Zero values
+// are ignored.
+type InboundMessageFilter struct {
+	Recipient   string
+	FromEmail   string
+	Tag         string
+	Subject     string
+	MailboxHash string
+	// one of "blocked", "processed", "queued", "failed" or "scheduled"
+	Status   string
+	FromDate time.Time
+	ToDate   time.Time
+}
+
+func (f *InboundMessageFilter) values(params url.Values) {
+	if f == nil {
+		return
+	}
+	setParam(params, "recipient", f.Recipient)
+	setParam(params, "fromemail", f.FromEmail)
+	setParam(params, "tag", f.Tag)
+	setParam(params, "subject", f.Subject)
+	setParam(params, "mailboxhash", f.MailboxHash)
+	setParam(params, "status", f.Status)
+	setTimeParam(params, "fromdate", f.FromDate)
+	setTimeParam(params, "todate", f.ToDate)
+}
+
+// InboundMessageList defines a list of received messages
+type InboundMessageList struct {
+	TotalCount      int64
+	InboundMessages []*InboundMessage
+}
+
+func (m *messages) InboundSearch(ctx context.Context, count, offset int, filter *InboundMessageFilter) (*InboundMessageList, error) {
+	params := url.Values{
+		"count":  {strconv.Itoa(count)},
+		"offset": {strconv.Itoa(offset)},
+	}
+	filter.values(params)
+
+	msgList := new(InboundMessageList)
+	_, err := m.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("messages", "inbound"),
+		Params: params,
+		Target: msgList,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return msgList, nil
+}
+
+// InboundMessageDetails defines a received message along with its content and processing history
+type InboundMessageDetails struct {
+	InboundMessage
+
+	TextBody      string
+	HTMLBody      string `json:"HtmlBody"`
+	Headers       []InboundHeader
+	BlockedReason string
+}
+
+func (m *messages) InboundDetails(ctx context.Context, messageID string) (*InboundMessageDetails, error) {
+	details := new(InboundMessageDetails)
+	_, err := m.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("messages", "inbound", messageID, "details"),
+		Target: details,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+func (m *messages) Bypass(ctx context.Context, messageID string) (*StatusResp, error) {
+	resp := new(StatusResp)
+	_, err := m.pm.Exec(ctx, &Request{
+		Method: "PUT",
+		Path:   path.Join("messages", "inbound", messageID, "bypass"),
+		Target: resp,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (m *messages) Retry(ctx context.Context, messageID string) (*StatusResp, error) {
+	resp := new(StatusResp)
+	_, err := m.pm.Exec(ctx, &Request{
+		Method: "PUT",
+		Path:   path.Join("messages", "inbound", messageID, "retry"),
+		Target: resp,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Open defines the opening of a sent message. The same fields are sent in open webhooks.
+type Open struct {
+	FirstOpen   bool
+	Client      OpenContext
+	OS          OpenContext
+	Platform    string
+	UserAgent   string
+	ReadSeconds float64
+	Geo         OpenGeolocation
+	MessageID   string
+	ReceivedAt  Time
+	Tag         string
+	Recipient   string
+	Metadata    map[string]string
+}
+
+// Click defines a click on a tracked link within a sent message
+type Click struct {
+	// where the link was in the message, either "HTML" or "Text"
+	ClickLocation string
+	Client        OpenContext
+	OS            OpenContext
+	Platform      string
+	UserAgent     string
+	OriginalLink  string
+	Geo           OpenGeolocation
+	MessageID     string
+	ReceivedAt    Time
+	Tag           string
+	Recipient     string
+	Metadata      map[string]string
+}
+
+// TrackingFilter defines the criteria opens and clicks can be searched by. Zero values are
+// ignored.
+type TrackingFilter struct {
+	Recipient     string
+	Tag           string
+	ClientName    string
+	ClientCompany string
+	ClientFamily  string
+	OSName        string
+	OSFamily      string
+	OSCompany     string
+	Platform      string
+	Country       string
+	Region        string
+	City          string
+}
+
+func (f *TrackingFilter) values(params url.Values) {
+	if f == nil {
+		return
+	}
+	setParam(params, "recipient", f.Recipient)
+	setParam(params, "tag", f.Tag)
+	setParam(params, "client_name", f.ClientName)
+	setParam(params, "client_company", f.ClientCompany)
+	setParam(params, "client_family", f.ClientFamily)
+	setParam(params, "os_name", f.OSName)
+	setParam(params, "os_family", f.OSFamily)
+	setParam(params, "os_company", f.OSCompany)
+	setParam(params, "platform", f.Platform)
+	setParam(params, "country", f.Country)
+	setParam(params, "region", f.Region)
+	setParam(params, "city", f.City)
+}
+
+// OpenList defines a list of opens
+type OpenList struct {
+	TotalCount int64
+	Opens      []*Open
+}
+
+func (m *messages) Opens(ctx context.Context, count, offset int, filter *TrackingFilter) (*OpenList, error) {
+	params := url.Values{
+		"count":  {strconv.Itoa(count)},
+		"offset": {strconv.Itoa(offset)},
+	}
+	filter.values(params)
+
+	openList := new(OpenList)
+	_, err := m.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("messages", "outbound", "opens"),
+		Params: params,
+		Target: openList,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return openList, nil
+}
+
+func (m *messages) MessageOpens(ctx context.Context, messageID string, count, offset int) (*OpenList, error) {
+	openList := new(OpenList)
+	_, err := m.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("messages", "outbound", "opens", messageID),
+		Params: url.Values{
+			"count":  {strconv.Itoa(count)},
+			"offset": {strconv.Itoa(offset)},
+		},
+		Target: openList,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return openList, nil
+}
+
+// ClickList defines a list of clicks
+type ClickList struct {
+	TotalCount int64
+	Clicks     []*Click
+}
+
+func (m *messages) Clicks(ctx context.Context, count, offset int, filter *TrackingFilter) (*ClickList, error) {
+	params := url.Values{
+		"count":  {strconv.Itoa(count)},
+		"offset": {strconv.Itoa(offset)},
+	}
+	filter.values(params)
+
+	clickList := new(ClickList)
+	_, err := m.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("messages", "outbound", "clicks"),
+		Params: params,
+		Target: clickList,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return clickList, nil
+}
+
+func (m *messages) MessageClicks(ctx context.Context, messageID string, count, offset int) (*ClickList, error) {
+	clickList := new(ClickList)
+	_, err := m.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("messages", "outbound", "clicks", messageID),
+		Params: url.Values{
+			"count":  {strconv.Itoa(count)},
+			"offset": {strconv.Itoa(offset)},
+		},
+		Target: clickList,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return clickList, nil
+}
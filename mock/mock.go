@@ -108,11 +108,70 @@ func (m *mock) Templates() Templates {
 	return tmpl
 }
 
+func (m *mock) Bounces() Bounces {
+	return m.parent.Bounces()
+}
+
+func (m *mock) Messages() Messages {
+	return m.parent.Messages()
+}
+
+func (m *mock) Server() CurrentServer {
+	return m.parent.Server()
+}
+
+func (m *mock) Servers() Servers {
+	return m.parent.Servers()
+}
+
+func (m *mock) SenderSignatures() SenderSignatures {
+	return m.parent.SenderSignatures()
+}
+
+func (m *mock) Domains() Domains {
+	return m.parent.Domains()
+}
+
+func (m *mock) Stats() Stats {
+	return m.parent.Stats()
+}
+
+func (m *mock) Triggers() Triggers {
+	return m.parent.Triggers()
+}
+
+func (m *mock) MessageStreams() MessageStreams {
+	return m.parent.MessageStreams()
+}
+
+func (m *mock) Suppressions() Suppressions {
+	return m.parent.Suppressions()
+}
+
+func (m *mock) Webhooks() Webhooks {
+	return m.parent.Webhooks()
+}
+
 func (m *mock) SetClient(client *http.Client) Postmark {
 	m.parent = m.parent.SetClient(client)
 	return m
 }
 
+func (m *mock) SetRetryPolicy(policy *RetryPolicy) Postmark {
+	m.parent = m.parent.SetRetryPolicy(policy)
+	return m
+}
+
+func (m *mock) SetLimiter(limiter Limiter) Postmark {
+	m.parent = m.parent.SetLimiter(limiter)
+	return m
+}
+
+func (m *mock) SetMaxInFlight(n int) Postmark {
+	m.parent = m.parent.SetMaxInFlight(n)
+	return m
+}
+
 func (m *mockEmails) real() Emails {
 	return m.parent.parent.Emails()
 }
@@ -121,7 +180,18 @@ func (m *mockEmails) Email(ctx context.Context, email *Email) (*EmailResponse, e
 	return m.real().Email(ctx, email)
 }
 
+func (m *mockEmails) Batch(ctx context.Context, emails []*Email) ([]*EmailResponse, error) {
+	return m.real().Batch(ctx, emails)
+}
+
 func (m *mockEmails) EmailWithTemplate(_ context.Context, email *EmailWithTemplate) (*EmailResponse, error) {
+	if email == nil {
+		return nil, errors.New("postmark: email is nil")
+	}
+	if err := ValidateMetadata(email.Metadata); err != nil {
+		return nil, err
+	}
+
 	id, err := strconv.Atoi(email.TemplateID)
 	if err != nil {
 		return nil, err
@@ -175,6 +245,24 @@ outerLoop:
 	}, err
 }
 
+func (m *mockEmails) BatchWithTemplate(ctx context.Context, emails []*EmailWithTemplate) ([]*EmailResponse, error) {
+	ers := make([]*EmailResponse, 0, len(emails))
+	for _, email := range emails {
+		er, err := m.EmailWithTemplate(ctx, email)
+		if pmerr, ok := err.(*Error); ok {
+			er = &EmailResponse{
+				To:        email.To,
+				ErrorCode: pmerr.ErrorCode,
+				Message:   pmerr.Message,
+			}
+		} else if err != nil {
+			return ers, err
+		}
+		ers = append(ers, er)
+	}
+	return ers, nil
+}
+
 func (m *mockTemplates) Get(_ context.Context, id int64) (*Template, error) {
 	ret, ok := tmplInfo[id]
 	if !ok {
@@ -253,3 +341,7 @@ func (m *mockTemplates) Validate(_ context.Context, tmpl *TemplateValidation) (*
 func (m *mockTemplates) Email(ctx context.Context, email *EmailWithTemplate) (*EmailResponse, error) {
 	return eml.EmailWithTemplate(ctx, email)
 }
+
+func (m *mockTemplates) EmailBatch(ctx context.Context, emails []*EmailWithTemplate) ([]*EmailResponse, error) {
+	return eml.BatchWithTemplate(ctx, emails)
+}
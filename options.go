@@ -0,0 +1,89 @@
+package postmark
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Option configures a client created with New.
+type Option func(*postmark)
+
+// WithBaseURL sends calls to the given URL instead of the Postmark API, for example to a proxy or
+// to a fake server in tests. The paths of calls are appended to the path of the URL.
+func WithBaseURL(u *url.URL) Option {
+	return func(p *postmark) {
+		p.scheme = u.Scheme
+		p.host = u.Host
+		p.basePath = strings.TrimSuffix(u.Path, "/")
+	}
+}
+
+// WithHTTPClient makes calls with the given client instead of http.DefaultClient. It is the same
+// as calling SetClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(p *postmark) {
+		p.client = client
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every call.
+func WithUserAgent(userAgent string) Option {
+	return func(p *postmark) {
+		p.userAgent = userAgent
+	}
+}
+
+// WithTimeout limits the duration of every attempt of a call, including reading its response.
+// Unlike a deadline on the context of a call, attempts that time out can be retried.
+func WithTimeout(timeout time.Duration) Option {
+	return func(p *postmark) {
+		p.timeout = timeout
+	}
+}
+
+// WithRetryPolicy is the same as calling SetRetryPolicy.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(p *postmark) {
+		p.SetRetryPolicy(policy)
+	}
+}
+
+// WithLimiter is the same as calling SetLimiter.
+func WithLimiter(limiter Limiter) Option {
+	return func(p *postmark) {
+		p.SetLimiter(limiter)
+	}
+}
+
+// WithMaxInFlight is the same as calling SetMaxInFlight.
+func WithMaxInFlight(n int) Option {
+	return func(p *postmark) {
+		p.SetMaxInFlight(n)
+	}
+}
+
+// WithLogger sends the diagnostics of the client to the given logger, such as one returned by
+// NewStdLogger. A nil logger disables logging.
+func WithLogger(logger Logger) Option {
+	return func(p *postmark) {
+		if logger == nil {
+			logger = nopLogger{}
+		}
+		p.logger = logger
+	}
+}
+
+// WithRequestHook calls hook with every request right before it is sent, for example to add
+// headers required by a proxy. It is a shorthand for WithHooks with only BeforeRequest set.
+func WithRequestHook(hook func(r *http.Request)) Option {
+	return WithHooks(Hooks{
+		BeforeRequest: func(_ context.Context, _ *Request, r *http.Request) context.Context {
+			hook(r)
+			return nil
+		},
+	})
+}
@@ -6,9 +6,9 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
+	"path"
 	"strings"
 	"time"
 
@@ -26,21 +26,78 @@ const (
 type Postmark interface {
 	SetClient(client *http.Client) Postmark
 
+	// SetRetryPolicy sets how calls failing with a transient error are retried. Calls are not
+	// retried if the policy is nil, which is the default.
+	SetRetryPolicy(policy *RetryPolicy) Postmark
+
+	// SetLimiter limits the rate of calls made by the client and all of its resources, such as
+	// a Limiter returned by NewRateLimiter. Calls wait for the limiter, or until their context
+	// is done. The rate is not limited if the limiter is nil, which is the default.
+	SetLimiter(limiter Limiter) Postmark
+
+	// SetMaxInFlight limits the number of calls the client and all of its resources make at the
+	// same time. Further calls wait for one to finish, or until their context is done. Values
+	// below 1 remove the limit, which is the default.
+	SetMaxInFlight(n int) Postmark
+
 	// Templates returns a resource root object handling template interactions with Postmark
 	Templates() Templates
 
 	// Templates returns a resource root object handling template interactions with Postmark
 	Emails() Emails
+
+	// Bounces returns a resource root object handling bounce interactions with Postmark
+	Bounces() Bounces
+
+	// Messages returns a resource root object handling message search and details with Postmark
+	Messages() Messages
+
+	// Server returns a resource root object handling the settings of the current server
+	Server() CurrentServer
+
+	// Servers returns a resource root object handling the servers of the account
+	Servers() Servers
+
+	// SenderSignatures returns a resource root object handling the sender signatures of the account
+	SenderSignatures() SenderSignatures
+
+	// Domains returns a resource root object handling the sending domains of the account
+	Domains() Domains
+
+	// Stats returns a resource root object handling outbound statistics with Postmark
+	Stats() Stats
+
+	// Triggers returns a resource root object handling tag and inbound rule triggers with Postmark
+	Triggers() Triggers
+
+	// MessageStreams returns a resource root object handling the message streams of the server
+	MessageStreams() MessageStreams
+
+	// Suppressions returns a resource root object handling the suppressed addresses of message streams
+	Suppressions() Suppressions
+
+	// Webhooks returns a resource root object handling the webhook configurations of the server
+	Webhooks() Webhooks
 }
 
 type postmark struct {
 	serverToken  string
 	accountToken string
 
-	scheme string
-	host   string
+	scheme   string
+	host     string
+	basePath string
+
+	client      *http.Client
+	userAgent   string
+	timeout     time.Duration
+	retryPolicy *RetryPolicy
+	logger      Logger
+	hooks       []Hooks
 
-	client *http.Client
+	// shared by all resources, so that limits apply to the client as a whole
+	limiter  Limiter
+	inFlight chan struct{}
 }
 
 // Request is an general container for requests sent with Postmark
@@ -55,14 +112,19 @@ type Request struct {
 	AccountAuth bool
 }
 
-// New returns an initialized Postmark client
-func New(serverToken, accountToken string) Postmark {
-	return &postmark{
+// New returns an initialized Postmark client, configured with the given options
+func New(serverToken, accountToken string, opts ...Option) Postmark {
+	p := &postmark{
 		serverToken:  serverToken,
 		accountToken: accountToken,
 		scheme:       "https",
 		host:         apiHost,
+		logger:       nopLogger{},
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 func (p *postmark) Templates() Templates {
@@ -73,29 +135,149 @@ func (p *postmark) Emails() Emails {
 	return &emails{pm: p}
 }
 
+func (p *postmark) Bounces() Bounces {
+	return &bounces{pm: p}
+}
+
+func (p *postmark) Messages() Messages {
+	return &messages{pm: p}
+}
+
+func (p *postmark) Server() CurrentServer {
+	return &currentServer{pm: p}
+}
+
+func (p *postmark) Servers() Servers {
+	return &servers{pm: p}
+}
+
+func (p *postmark) SenderSignatures() SenderSignatures {
+	return &senderSignatures{pm: p}
+}
+
+func (p *postmark) Domains() Domains {
+	return &domains{pm: p}
+}
+
+func (p *postmark) Stats() Stats {
+	return &stats{pm: p}
+}
+
+func (p *postmark) Triggers() Triggers {
+	return &triggers{pm: p}
+}
+
+func (p *postmark) MessageStreams() MessageStreams {
+	return &messageStreams{pm: p}
+}
+
+func (p *postmark) Suppressions() Suppressions {
+	return &suppressions{pm: p}
+}
+
+func (p *postmark) Webhooks() Webhooks {
+	return &webhooks{pm: p}
+}
+
 func (p *postmark) Exec(ctx context.Context, req *Request) (*http.Response, error) {
-	var payload io.Reader
+	var data []byte
 	if req.Payload != nil {
-		data, err := json.Marshal(req.Payload)
-		if err != nil {
+		var err error
+		if data, err = json.Marshal(req.Payload); err != nil {
 			return nil, err
 		}
+	}
 
+	for attempt := 1; ; attempt++ {
+		resp, err := p.attempt(ctx, req, data)
+		if err == nil {
+			return resp, nil
+		}
+
+		delay, retry := p.retryPolicy.delay(attempt, req, resp, err)
+		if terr, ok := err.(*transportError); ok {
+			err = terr.err
+		}
+		if !retry || ctx.Err() != nil {
+			return resp, err
+		}
+
+		p.logger.Log(LogLevelInfo, "retrying postmark call", map[string]interface{}{
+			"method":  req.Method,
+			"path":    req.Path,
+			"attempt": attempt,
+			"delay":   delay,
+			"error":   err,
+		})
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			p.afterResponse(ctx, req, nil, 0, ctx.Err())
+			return resp, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt makes a single call to the API. Errors of calls that did not get a response are
+// returned as a *transportError.
+func (p *postmark) attempt(ctx context.Context, req *Request, data []byte) (_ *http.Response, err error) {
+	var payload io.Reader
+	if data != nil {
 		payload = bytes.NewReader(data)
 	}
 
 	urlBuilder := url.URL{
 		Scheme:   p.scheme,
 		Host:     p.host,
-		Path:     req.Path,
+		Path:     path.Join(p.basePath, req.Path),
 		RawQuery: req.Params.Encode(), // returns "" if nil
 	}
 
+	// hooks are also called for attempts failing before a request is sent, with the caller's
+	// context rather than the one bounded by the timeout, which is cancelled by then
+	hookCtx := ctx
+	var resp *http.Response
+	var start time.Time
+	defer func() {
+		var elapsed time.Duration
+		if !start.IsZero() {
+			elapsed = time.Since(start)
+		}
+		if resp != nil {
+			p.logger.Log(LogLevelDebug, "received postmark response", map[string]interface{}{
+				"method":  req.Method,
+				"path":    req.Path,
+				"status":  resp.StatusCode,
+				"elapsed": elapsed,
+			})
+		}
+		// resp is kept even when decoding the target fails, so hooks see every response
+		p.afterResponse(hookCtx, req, resp, elapsed, err)
+	}()
+
 	r, err := http.NewRequest(req.Method, urlBuilder.String(), payload)
 	if err != nil {
 		return nil, err
 	}
 
+	release, err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// waiting for the limits does not count towards the timeout
+	attemptCtx := ctx
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		attemptCtx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+	r = r.WithContext(attemptCtx)
+
 	r.Header.Set("Accept", "application/json")
 	r.Header.Set("Content-Type", "application/json")
 
@@ -104,10 +286,28 @@ func (p *postmark) Exec(ctx context.Context, req *Request) (*http.Response, erro
 	} else {
 		r.Header.Set(serverTokenHeader, p.serverToken)
 	}
+	if p.userAgent != "" {
+		r.Header.Set("User-Agent", p.userAgent)
+	}
+	hookCtx = p.beforeRequest(ctx, req, r)
+
+	if p.logger.Enabled(LogLevelDebug) {
+		fields := map[string]interface{}{
+			"method":  req.Method,
+			"path":    req.Path,
+			"params":  req.Params.Encode(),
+			"headers": redactHeaders(r.Header),
+		}
+		if data != nil {
+			fields["payload"] = redactPayload(data)
+		}
+		p.logger.Log(LogLevelDebug, "sending postmark request", fields)
+	}
 
-	resp, err := p.httpclient().Do(r)
+	start = time.Now()
+	resp, err = p.httpclient().Do(r)
 	if err != nil {
-		return nil, err
+		return nil, &transportError{err}
 	}
 	defer resp.Body.Close()
 
@@ -117,10 +317,16 @@ func (p *postmark) Exec(ctx context.Context, req *Request) (*http.Response, erro
 
 		// handle non-json responses
 		if !strings.Contains(resp.Header.Get("Content-Type"), "application/json") {
-			log.Printf("req: %+v", r)
-
 			respData, _ := ioutil.ReadAll(resp.Body)
 			pmerr.Message = string(respData)
+
+			p.logger.Log(LogLevelWarn, "postmark responded with a non-JSON error", map[string]interface{}{
+				"method":  req.Method,
+				"path":    req.Path,
+				"status":  resp.StatusCode,
+				"headers": redactHeaders(r.Header),
+				"body":    truncate(pmerr.Message, 512),
+			})
 			return resp, pmerr
 		}
 
@@ -154,6 +360,25 @@ func (p *postmark) SetClient(client *http.Client) Postmark {
 	return p
 }
 
+func (p *postmark) SetRetryPolicy(policy *RetryPolicy) Postmark {
+	p.retryPolicy = policy
+	return p
+}
+
+func (p *postmark) SetLimiter(limiter Limiter) Postmark {
+	p.limiter = limiter
+	return p
+}
+
+func (p *postmark) SetMaxInFlight(n int) Postmark {
+	if n < 1 {
+		p.inFlight = nil
+	} else {
+		p.inFlight = make(chan struct{}, n)
+	}
+	return p
+}
+
 // Error defines an error from the Postmark API
 type Error struct {
 	ErrorCode int
@@ -168,6 +393,13 @@ func (e *Error) IsError() bool {
 	return e.ErrorCode != 0
 }
 
+// Is reports whether target is an *Error with the same ErrorCode, so that errors returned by the
+// API can be matched against the predefined errors with errors.Is.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.ErrorCode != 0 && t.ErrorCode == e.ErrorCode
+}
+
 func (e *Error) Error() string {
 	if e.ErrorCode == 0 {
 		return fmt.Sprintf("postmark HTTP error %d: %s", e.StatusCode, e.Message)
@@ -180,12 +412,19 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("postmark error %d %s: %s", e.ErrorCode, e.Message, codeMeaning)
 }
 
+// StatusResp defines the response of calls that only report whether they succeeded
+type StatusResp struct {
+	ErrorCode int
+	Message   string
+}
+
 // Time wraps time.Time with more flexible parsing. It has only been necessary in a few cases,
 // using the stdlib's time.Time is preferred if possible.
 type Time time.Time
 
 const (
 	rfc3339NoTz = "2006-01-02T15:04:05"
+	dateOnly    = "2006-01-02"
 )
 
 // UnmarshalJSON implements more flexible parsing of timestamps for the Time type
@@ -197,8 +436,27 @@ func (t *Time) UnmarshalJSON(data []byte) error {
 
 	stdtime, err := time.Parse(`"`+rfc3339NoTz+`"`, string(data))
 	if err != nil {
-		return err
+		// some calls, such as those of the stats API, only return dates
+		var dateErr error
+		if stdtime, dateErr = time.Parse(`"`+dateOnly+`"`, string(data)); dateErr != nil {
+			return err
+		}
 	}
 	*t = (Time)(stdtime)
 	return nil
 }
+
+// setParam sets a query parameter, skipping empty values so that optional filters can be
+// assigned unconditionally.
+func setParam(params url.Values, key, value string) {
+	if value != "" {
+		params.Set(key, value)
+	}
+}
+
+// setTimeParam sets a date filter in the format expected by Postmark, skipping zero times.
+func setTimeParam(params url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		params.Set(key, t.Format(rfc3339NoTz))
+	}
+}
@@ -0,0 +1,342 @@
+package postmark
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// newTestClient returns a client calling the given handler instead of the Postmark API
+func newTestClient(t *testing.T, h http.HandlerFunc, opts ...Option) *postmark {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New("server-token", "account-token", append([]Option{WithBaseURL(u)}, opts...)...).(*postmark)
+}
+
+const maintenanceBody = `{"ErrorCode": 100, "Message": "Maintenance"}`
+
+// failTimes returns a handler failing n times with the given response before succeeding, and a
+// counter of the calls it received
+func failTimes(n int32, status int, header http.Header, body string) (http.HandlerFunc, *int32) {
+	calls := new(int32)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) <= n {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"TemplateId": 1}`))
+	}, calls
+}
+
+var testRetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+func TestExecRetries(t *testing.T) {
+	h, calls := failTimes(2, http.StatusServiceUnavailable, nil, maintenanceBody)
+	p := newTestClient(t, h)
+	p.SetRetryPolicy(testRetryPolicy)
+
+	tmpl, err := p.Templates().Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tmpl.TemplateID != 1 || *calls != 3 {
+		t.Errorf("expected template after 3 calls, got %+v after %d", tmpl, *calls)
+	}
+}
+
+func TestExecGivesUp(t *testing.T) {
+	h, calls := failTimes(5, http.StatusServiceUnavailable, nil, maintenanceBody)
+	p := newTestClient(t, h)
+	p.SetRetryPolicy(testRetryPolicy)
+
+	_, err := p.Templates().Get(context.Background(), 1)
+	if pmerr, ok := err.(*Error); !ok || pmerr.ErrorCode != 100 {
+		t.Errorf("expected maintenance error, got %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("expected 3 calls, got %d", *calls)
+	}
+}
+
+func TestExecOnlyRetriesSafeCalls(t *testing.T) {
+	// a POST failing with a generic server error may have been carried out
+	h, calls := failTimes(1, http.StatusInternalServerError, nil, `{"Message": "Internal error"}`)
+	p := newTestClient(t, h)
+	p.SetRetryPolicy(testRetryPolicy)
+
+	if _, err := p.Templates().Create(context.Background(), &Template{}); err == nil {
+		t.Errorf("expected error")
+	}
+	if *calls != 1 {
+		t.Errorf("expected a single call, got %d", *calls)
+	}
+
+	// a rate limited POST was not
+	h, calls = failTimes(1, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}}, `{"Message": "Too many requests"}`)
+	p = newTestClient(t, h)
+	p.SetRetryPolicy(testRetryPolicy)
+
+	if _, err := p.Templates().Create(context.Background(), &Template{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if *calls != 2 {
+		t.Errorf("expected 2 calls, got %d", *calls)
+	}
+}
+
+func TestExecRetryCancel(t *testing.T) {
+	h, calls := failTimes(5, http.StatusServiceUnavailable, nil, maintenanceBody)
+	p := newTestClient(t, h)
+	p.SetRetryPolicy(&RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := p.Templates().Get(ctx, 1); err != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if *calls != 1 {
+		t.Errorf("expected a single call, got %d", *calls)
+	}
+}
+
+func TestRetryDelayOverflow(t *testing.T) {
+	req := &Request{Method: "GET"}
+	err := &transportError{errors.New("connection reset")}
+
+	rp := &RetryPolicy{MaxAttempts: 1000, BaseDelay: 500 * time.Millisecond}
+	if d, ok := rp.delay(100, req, nil, err); !ok || d <= 0 {
+		t.Errorf("expected a positive delay, got %v, %v", d, ok)
+	}
+
+	rp.MaxDelay = 30 * time.Second
+	if d, ok := rp.delay(100, req, nil, err); !ok || d != rp.MaxDelay {
+		t.Errorf("expected a delay of %v, got %v, %v", rp.MaxDelay, d, ok)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/proxy/templates/1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if ua := r.Header.Get("User-Agent"); ua != "test-agent" {
+			t.Errorf("unexpected user agent: %s", ua)
+		}
+		if r.Header.Get("X-Proxy-Auth") != "secret" {
+			t.Errorf("expected request hook to set proxy header")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"TemplateId": 1}`))
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL + "/proxy/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := New("server-token", "",
+		WithBaseURL(u),
+		WithUserAgent("test-agent"),
+		WithLogger(nil),
+		WithRequestHook(func(r *http.Request) { r.Header.Set("X-Proxy-Auth", "secret") }),
+	)
+
+	if _, err := p.Templates().Get(context.Background(), 1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestHooks(t *testing.T) {
+	h, _ := failTimes(1, http.StatusServiceUnavailable, nil, maintenanceBody)
+
+	var before, statuses, errorCodes []int
+	p := newTestClient(t, h,
+		WithRetryPolicy(testRetryPolicy),
+		WithHooks(Hooks{
+			BeforeRequest: func(_ context.Context, req *Request, _ *http.Request) context.Context {
+				before = append(before, len(req.Path))
+				return nil
+			},
+			AfterResponse: func(_ context.Context, _ *Request, resp *http.Response, _ time.Duration) {
+				statuses = append(statuses, resp.StatusCode)
+			},
+			OnError: func(_ context.Context, _ *Request, _ *http.Response, err error) {
+				if pmerr, ok := err.(*Error); ok {
+					errorCodes = append(errorCodes, pmerr.ErrorCode)
+				}
+			},
+		}),
+	)
+
+	if _, err := p.Templates().Get(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(before) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(before))
+	}
+	if len(statuses) != 2 || statuses[0] != http.StatusServiceUnavailable || statuses[1] != http.StatusOK {
+		t.Errorf("unexpected statuses: %v", statuses)
+	}
+	if len(errorCodes) != 1 || errorCodes[0] != 100 {
+		t.Errorf("unexpected error codes: %v", errorCodes)
+	}
+}
+
+type spanKey struct{}
+
+func TestHooksContext(t *testing.T) {
+	var calls int32
+	var spans []string
+	check := func(ctx context.Context) {
+		if err := ctx.Err(); err != nil {
+			t.Errorf("hook called with a done context: %v", err)
+		}
+		span, _ := ctx.Value(spanKey{}).(string)
+		spans = append(spans, span)
+	}
+	p := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// fail the first call without any response
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("error hijacking connection: %v", err)
+				return
+			}
+			conn.Close()
+			return
+		}
+		fmt.Fprint(w, "{}")
+	}, WithTimeout(time.Second), WithHooks(Hooks{
+		BeforeRequest: func(ctx context.Context, _ *Request, _ *http.Request) context.Context {
+			return context.WithValue(ctx, spanKey{}, fmt.Sprintf("span-%d", atomic.LoadInt32(&calls)))
+		},
+		AfterResponse: func(ctx context.Context, _ *Request, _ *http.Response, _ time.Duration) {
+			check(ctx)
+		},
+		OnError: func(ctx context.Context, _ *Request, _ *http.Response, _ error) {
+			check(ctx)
+		},
+	}))
+
+	if _, err := p.Templates().Get(context.Background(), 1); err == nil {
+		t.Errorf("expected a transport error")
+	}
+	if _, err := p.Templates().Get(context.Background(), 1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(spans) != 2 || spans[0] != "span-0" || spans[1] != "span-1" {
+		t.Errorf("unexpected spans: %v", spans)
+	}
+}
+
+// recordingLogger keeps every message logged at any level
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Enabled(LogLevel) bool { return true }
+
+func (l *recordingLogger) Log(level LogLevel, msg string, fields map[string]interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf("%s %s %v", level, msg, fields))
+}
+
+func TestHooksWithoutResponse(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	var errs []error
+	p := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		fmt.Fprint(w, "{}")
+	}, WithMaxInFlight(1), WithHooks(Hooks{
+		OnError: func(_ context.Context, _ *Request, resp *http.Response, err error) {
+			if resp != nil {
+				t.Errorf("unexpected response: %v", resp.Status)
+			}
+			errs = append(errs, err)
+		},
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := p.Templates().Get(context.Background(), 1); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}()
+	<-started
+
+	// the second call times out waiting for the first one to complete
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := p.Templates().Get(ctx, 1); err != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	close(release)
+	<-done
+
+	// the third call is cancelled while waiting to be retried
+	p = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, WithRetryPolicy(&RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour}), WithHooks(Hooks{
+		OnError: func(_ context.Context, _ *Request, _ *http.Response, err error) {
+			errs = append(errs, err)
+		},
+	}))
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := p.Templates().Get(ctx, 1); err != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+
+	if len(errs) != 3 || errs[0] != context.DeadlineExceeded || errs[2] != context.DeadlineExceeded {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+func TestLoggerRedaction(t *testing.T) {
+	logger := &recordingLogger{}
+	p := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html>Bad Gateway</html>"))
+	}, WithLogger(logger))
+
+	_, err := p.Emails().Email(context.Background(), &Email{
+		BaseEmail: BaseEmail{To: "john@example.com"},
+		Subject:   "Password reset",
+		HTMLBody:  "<a href='https://example.com/reset?token=hunter2'>Reset</a>",
+	})
+	if pmerr, ok := err.(*Error); !ok || pmerr.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected bad gateway error, got %v", err)
+	}
+
+	log := strings.Join(logger.lines, "\n")
+	if !strings.Contains(log, "non-JSON error") || !strings.Contains(log, "Password reset") {
+		t.Errorf("expected request and error to be logged, got:\n%s", log)
+	}
+	for _, secret := range []string{"server-token", "hunter2"} {
+		if strings.Contains(log, secret) {
+			t.Errorf("expected %q to be redacted, got:\n%s", secret, log)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package postmark
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy defines how calls to the API are retried after a transient failure. Calls are only
+// retried when that cannot lead to them being carried out twice: those rejected with a 429 or
+// during maintenance (ErrorCode 100) are always retried, while those failing with a network error
+// or another 5xx status are only retried for idempotent methods (GET, PUT and DELETE).
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a call, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry, which is doubled for every further retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two attempts. Calls are not retried if the API asks to
+	// wait longer than this through the Retry-After header. Zero means no cap.
+	MaxDelay time.Duration
+
+	// Jitter is the fraction of every delay that is randomized, between 0 and 1, so that many
+	// clients failing at once do not retry at the same time.
+	Jitter float64
+}
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for most uses. Clients do not retry calls
+// unless a policy is set with SetRetryPolicy.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.5,
+	}
+}
+
+// transportError marks errors of a call that did not return any response
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+// delay returns how long to wait before retrying a call that failed with the given response and
+// error, or false if it should not be retried.
+func (rp *RetryPolicy) delay(attempt int, req *Request, resp *http.Response, err error) (time.Duration, bool) {
+	if rp == nil || attempt >= rp.MaxAttempts {
+		return 0, false
+	}
+
+	if _, ok := err.(*transportError); ok {
+		if !idempotent(req.Method) {
+			return 0, false
+		}
+	} else if resp == nil {
+		return 0, false
+	} else if pmerr, ok := err.(*Error); ok && pmerr.ErrorCode == 100 {
+		// the API is under maintenance and did not process the call
+	} else if resp.StatusCode == http.StatusTooManyRequests {
+		// the call was rate limited and not processed
+	} else if resp.StatusCode < 500 || !idempotent(req.Method) {
+		return 0, false
+	}
+
+	d := rp.BaseDelay
+	for i := 1; i < attempt && d > 0 && d <= math.MaxInt64/2; i++ {
+		if rp.MaxDelay > 0 && d >= rp.MaxDelay {
+			break
+		}
+		d *= 2
+	}
+	if rp.MaxDelay > 0 && d > rp.MaxDelay {
+		d = rp.MaxDelay
+	}
+	if rp.Jitter > 0 && d > 0 {
+		d -= time.Duration(rand.Float64() * rp.Jitter * float64(d))
+	}
+
+	if resp != nil {
+		if after, ok := retryAfter(resp.Header.Get("Retry-After")); ok && after > d {
+			if rp.MaxDelay > 0 && after > rp.MaxDelay {
+				return 0, false
+			}
+			d = after
+		}
+	}
+	return d, true
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a number of seconds or a
+// date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date), true
+	}
+	return 0, false
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,225 @@
+package postmark
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+
+	"golang.org/x/net/context"
+)
+
+// SenderSignatures defines the functionality of the sender signatures resource. All of its calls
+// use the account token.
+type SenderSignatures interface {
+	// List returns the sender signatures of the account
+	// http://developer.postmarkapp.com/developer-api-signatures.html#list-sender-signatures
+	List(ctx context.Context, count, offset int) (*SenderSignatureList, error)
+
+	// Get retrieves an individual sender signature
+	// http://developer.postmarkapp.com/developer-api-signatures.html#get-sender-signature
+	Get(ctx context.Context, id int64) (*SenderSignature, error)
+
+	// Create creates a new sender signature and sends a confirmation email to its address.
+	// Signatures for public domains are rejected with ErrPublicDomain, and existing ones with
+	// ErrSenderSignatureExists.
+	// http://developer.postmarkapp.com/developer-api-signatures.html#create-signature
+	Create(ctx context.Context, sig *SenderSignatureReq) (*SenderSignature, error)
+
+	// Edit modifies an existing sender signature. The FromEmail of the request is ignored, as
+	// the address of a signature cannot be changed.
+	// http://developer.postmarkapp.com/developer-api-signatures.html#edit-signature
+	Edit(ctx context.Context, id int64, sig *SenderSignatureReq) (*SenderSignature, error)
+
+	// Delete permanently deletes a sender signature from Postmark
+	// http://developer.postmarkapp.com/developer-api-signatures.html#delete-signature
+	Delete(ctx context.Context, id int64) (*StatusResp, error)
+
+	// ResendConfirmation sends the confirmation email of a sender signature again
+	// http://developer.postmarkapp.com/developer-api-signatures.html#resend-confirmation
+	ResendConfirmation(ctx context.Context, id int64) (*StatusResp, error)
+
+	// VerifySPF checks the SPF record of the sender signature's domain
+	// http://developer.postmarkapp.com/developer-api-signatures.html#verify-spf
+	VerifySPF(ctx context.Context, id int64) (*SenderSignature, error)
+
+	// RequestNewDKIM creates a new DKIM key for the sender signature's domain. The new key has
+	// to be added to DNS before it replaces the current one.
+	// http://developer.postmarkapp.com/developer-api-signatures.html#request-dkim
+	RequestNewDKIM(ctx context.Context, id int64) (*StatusResp, error)
+}
+
+type senderSignatures struct {
+	pm *postmark
+}
+
+var _ SenderSignatures = (*senderSignatures)(nil)
+
+// SenderSignature defines the sender signature entities within postmark. Listing signatures only
+// returns their ID, addresses, name and confirmation status.
+type SenderSignature struct {
+	ID                  int64
+	Domain              string
+	EmailAddress        string
+	ReplyToEmailAddress string
+	Name                string
+	Confirmed           bool
+
+	SPFVerified  bool
+	SPFHost      string
+	SPFTextValue string
+
+	DKIMVerified                  bool
+	WeakDKIM                      bool
+	DKIMHost                      string
+	DKIMTextValue                 string
+	DKIMPendingHost               string
+	DKIMPendingTextValue          string
+	DKIMRevokedHost               string
+	DKIMRevokedTextValue          string
+	SafeToRemoveRevokedKeyFromDNS bool
+	DKIMUpdateStatus              string
+
+	ReturnPathDomain           string
+	ReturnPathDomainVerified   bool
+	ReturnPathDomainCNAMEValue string
+
+	ConfirmationPersonalNote string
+}
+
+// SenderSignatureReq defines the fields of a sender signature that can be set when creating or
+// editing it
+type SenderSignatureReq struct {
+	FromEmail                string `json:",omitempty"`
+	Name                     string `json:",omitempty"`
+	ReplyToEmail             string `json:",omitempty"`
+	ReturnPathDomain         string `json:",omitempty"`
+	ConfirmationPersonalNote string `json:",omitempty"`
+}
+
+// SenderSignatureList defines a list of sender signature entities
+type SenderSignatureList struct {
+	TotalCount       int64
+	SenderSignatures []*SenderSignature
+}
+
+func (s *senderSignatures) List(ctx context.Context, count, offset int) (*SenderSignatureList, error) {
+	sigList := new(SenderSignatureList)
+	_, err := s.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   "senders",
+		Params: url.Values{
+			"count":  {strconv.Itoa(count)},
+			"offset": {strconv.Itoa(offset)},
+		},
+		Target:      sigList,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sigList, nil
+}
+
+func (s *senderSignatures) Get(ctx context.Context, id int64) (*SenderSignature, error) {
+	sig := new(SenderSignature)
+	_, err := s.pm.Exec(ctx, &Request{
+		Method:      "GET",
+		Path:        path.Join("senders", i64toa(id)),
+		Target:      sig,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+func (s *senderSignatures) Create(ctx context.Context, req *SenderSignatureReq) (*SenderSignature, error) {
+	sig := new(SenderSignature)
+	_, err := s.pm.Exec(ctx, &Request{
+		Method:      "POST",
+		Path:        "senders",
+		Payload:     req,
+		Target:      sig,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+func (s *senderSignatures) Edit(ctx context.Context, id int64, req *SenderSignatureReq) (*SenderSignature, error) {
+	// the address of a signature cannot be changed, so it is not part of an edit
+	edit := *req
+	edit.FromEmail = ""
+
+	sig := new(SenderSignature)
+	_, err := s.pm.Exec(ctx, &Request{
+		Method:      "PUT",
+		Path:        path.Join("senders", i64toa(id)),
+		Payload:     &edit,
+		Target:      sig,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+func (s *senderSignatures) Delete(ctx context.Context, id int64) (*StatusResp, error) {
+	resp := new(StatusResp)
+	_, err := s.pm.Exec(ctx, &Request{
+		Method:      "DELETE",
+		Path:        path.Join("senders", i64toa(id)),
+		Target:      resp,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *senderSignatures) ResendConfirmation(ctx context.Context, id int64) (*StatusResp, error) {
+	resp := new(StatusResp)
+	_, err := s.pm.Exec(ctx, &Request{
+		Method:      "POST",
+		Path:        path.Join("senders", i64toa(id), "resend"),
+		Target:      resp,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *senderSignatures) VerifySPF(ctx context.Context, id int64) (*SenderSignature, error) {
+	sig := new(SenderSignature)
+	_, err := s.pm.Exec(ctx, &Request{
+		Method:      "POST",
+		Path:        path.Join("senders", i64toa(id), "verifyspf"),
+		Target:      sig,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+func (s *senderSignatures) RequestNewDKIM(ctx context.Context, id int64) (*StatusResp, error) {
+	resp := new(StatusResp)
+	_, err := s.pm.Exec(ctx, &Request{
+		Method:      "POST",
+		Path:        path.Join("senders", i64toa(id), "requestnewdkim"),
+		Target:      resp,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,84 @@
+package postmark
+
+import (
+	"golang.org/x/net/context"
+)
+
+// CurrentServer defines the functionality of the server resource, which manages the server that
+// the server token belongs to
+type CurrentServer interface {
+	// Get retrieves the settings of the server
+	// http://developer.postmarkapp.com/developer-api-server.html#get-server
+	Get(ctx context.Context) (*Server, error)
+
+	// Edit modifies the settings of the server. Only the fields that are set are changed.
+	// Invalid settings are reported as one of ErrInvalidWebhookURL, ErrInvalidServerColor,
+	// ErrInvalidServerName, ErrNoServerData, ErrInvalidInboundMX or ErrInvalidSpamThreshold.
+	// http://developer.postmarkapp.com/developer-api-server.html#edit-server
+	Edit(ctx context.Context, server *Server) (*Server, error)
+}
+
+type currentServer struct {
+	pm *postmark
+}
+
+var _ CurrentServer = (*currentServer)(nil)
+
+// Server defines the server entities within postmark. Pointer fields are optional when editing a
+// server, so that a setting can be changed to its zero value, for example a hook URL can be
+// removed by setting it to an empty string.
+type Server struct {
+	ID         int64    `json:",omitempty"`
+	Name       string   `json:",omitempty"`
+	APITokens  []string `json:"ApiTokens,omitempty"`
+	ServerLink string   `json:",omitempty"`
+	Color      string   `json:",omitempty"`
+
+	SMTPAPIActivated *bool  `json:"SmtpApiActivated,omitempty"`
+	RawEmailEnabled  *bool  `json:",omitempty"`
+	DeliveryType     string `json:",omitempty"`
+
+	InboundAddress       string  `json:",omitempty"`
+	InboundHookURL       *string `json:"InboundHookUrl,omitempty"`
+	InboundDomain        string  `json:",omitempty"`
+	InboundHash          string  `json:",omitempty"`
+	InboundSpamThreshold *int    `json:",omitempty"`
+
+	BounceHookURL              *string `json:"BounceHookUrl,omitempty"`
+	IncludeBounceContentInHook *bool   `json:",omitempty"`
+	OpenHookURL                *string `json:"OpenHookUrl,omitempty"`
+	PostFirstOpenOnly          *bool   `json:",omitempty"`
+	DeliveryHookURL            *string `json:"DeliveryHookUrl,omitempty"`
+	ClickHookURL               *string `json:"ClickHookUrl,omitempty"`
+	EnableSMTPAPIErrorHooks    *bool   `json:"EnableSmtpApiErrorHooks,omitempty"`
+
+	TrackOpens *bool         `json:",omitempty"`
+	TrackLinks LinkTrackType `json:",omitempty"`
+}
+
+func (s *currentServer) Get(ctx context.Context) (*Server, error) {
+	server := new(Server)
+	_, err := s.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   "server",
+		Target: server,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+func (s *currentServer) Edit(ctx context.Context, server *Server) (*Server, error) {
+	edited := new(Server)
+	_, err := s.pm.Exec(ctx, &Request{
+		Method:  "PUT",
+		Path:    "server",
+		Payload: server,
+		Target:  edited,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return edited, nil
+}
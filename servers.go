@@ -0,0 +1,126 @@
+package postmark
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+
+	"golang.org/x/net/context"
+)
+
+// Servers defines the functionality of the servers resource, which manages all servers of the
+// account. All of its calls use the account token.
+type Servers interface {
+	// List returns the servers of the account. If name is not empty, only servers whose name
+	// contains it are returned.
+	// http://developer.postmarkapp.com/developer-api-servers.html#list-servers
+	List(ctx context.Context, count, offset int, name string) (*ServerList, error)
+
+	// Get retrieves an individual server
+	// http://developer.postmarkapp.com/developer-api-servers.html#get-server
+	Get(ctx context.Context, id int64) (*Server, error)
+
+	// Create creates a new server within Postmark
+	// http://developer.postmarkapp.com/developer-api-servers.html#create-server
+	Create(ctx context.Context, server *Server) (*Server, error)
+
+	// Edit modifies an existing server. Only the fields that are set are changed.
+	// http://developer.postmarkapp.com/developer-api-servers.html#edit-server
+	Edit(ctx context.Context, id int64, server *Server) (*Server, error)
+
+	// Delete permanently deletes a server from Postmark. Deleting servers has to be enabled for
+	// the account by Postmark support, otherwise ErrNoDeleteAccess is returned.
+	// http://developer.postmarkapp.com/developer-api-servers.html#delete-server
+	Delete(ctx context.Context, id int64) (*StatusResp, error)
+}
+
+type servers struct {
+	pm *postmark
+}
+
+var _ Servers = (*servers)(nil)
+
+// ServerList defines a list of server entities
+type ServerList struct {
+	TotalCount int64
+	Servers    []*Server
+}
+
+func (s *servers) List(ctx context.Context, count, offset int, name string) (*ServerList, error) {
+	params := url.Values{
+		"count":  {strconv.Itoa(count)},
+		"offset": {strconv.Itoa(offset)},
+	}
+	setParam(params, "name", name)
+
+	serverList := new(ServerList)
+	_, err := s.pm.Exec(ctx, &Request{
+		Method:      "GET",
+		Path:        "servers",
+		Params:      params,
+		Target:      serverList,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return serverList, nil
+}
+
+func (s *servers) Get(ctx context.Context, id int64) (*Server, error) {
+	server := new(Server)
+	_, err := s.pm.Exec(ctx, &Request{
+		Method:      "GET",
+		Path:        path.Join("servers", i64toa(id)),
+		Target:      server,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+func (s *servers) Create(ctx context.Context, server *Server) (*Server, error) {
+	created := new(Server)
+	_, err := s.pm.Exec(ctx, &Request{
+		Method:      "POST",
+		Path:        "servers",
+		Payload:     server,
+		Target:      created,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *servers) Edit(ctx context.Context, id int64, server *Server) (*Server, error) {
+	edited := new(Server)
+	_, err := s.pm.Exec(ctx, &Request{
+		Method:      "PUT",
+		Path:        path.Join("servers", i64toa(id)),
+		Payload:     server,
+		Target:      edited,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return edited, nil
+}
+
+func (s *servers) Delete(ctx context.Context, id int64) (*StatusResp, error) {
+	resp := new(StatusResp)
+	_, err := s.pm.Exec(ctx, &Request{
+		Method:      "DELETE",
+		Path:        path.Join("servers", i64toa(id)),
+		Target:      resp,
+		AccountAuth: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
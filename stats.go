@@ -0,0 +1,354 @@
+package postmark
+
+import (
+	"encoding/json"
+	"net/url"
+	"path"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Stats defines the functionality of the stats resource. Every call accepts an optional filter,
+// and all except Overview return both the totals and a day-by-day series.
+type Stats interface {
+	// Overview returns a summary of all outbound statistics
+	// http://developer.postmarkapp.com/developer-api-stats.html#overview
+	Overview(ctx context.Context, filter *StatsFilter) (*OutboundOverview, error)
+
+	// SentCounts returns the number of sent emails
+	// http://developer.postmarkapp.com/developer-api-stats.html#sent-counts
+	SentCounts(ctx context.Context, filter *StatsFilter) (*SentCounts, error)
+
+	// BounceCounts returns the number of bounces by type
+	// http://developer.postmarkapp.com/developer-api-stats.html#bounce-counts
+	BounceCounts(ctx context.Context, filter *StatsFilter) (*BounceCounts, error)
+
+	// SpamComplaints returns the number of spam complaints
+	// http://developer.postmarkapp.com/developer-api-stats.html#spam-complaints
+	SpamComplaints(ctx context.Context, filter *StatsFilter) (*SpamComplaintCounts, error)
+
+	// TrackedCounts returns the number of sent emails with open tracking enabled
+	// http://developer.postmarkapp.com/developer-api-stats.html#tracked-email-counts
+	TrackedCounts(ctx context.Context, filter *StatsFilter) (*TrackedCounts, error)
+
+	// OpenCounts returns the number of opens
+	// http://developer.postmarkapp.com/developer-api-stats.html#email-open-counts
+	OpenCounts(ctx context.Context, filter *StatsFilter) (*OpenCounts, error)
+
+	// PlatformUsage returns the number of opens by platform
+	// http://developer.postmarkapp.com/developer-api-stats.html#email-platform-usage
+	PlatformUsage(ctx context.Context, filter *StatsFilter) (*PlatformUsage, error)
+
+	// EmailClientUsage returns the number of opens by email client
+	// http://developer.postmarkapp.com/developer-api-stats.html#email-client-usage
+	EmailClientUsage(ctx context.Context, filter *StatsFilter) (*NamedCounts, error)
+
+	// ReadTimes returns the number of opens by how many seconds the email was read for
+	// http://developer.postmarkapp.com/developer-api-stats.html#email-read-times
+	ReadTimes(ctx context.Context, filter *StatsFilter) (*NamedCounts, error)
+
+	// ClickCounts returns the number of clicks on tracked links
+	// http://developer.postmarkapp.com/developer-api-stats.html#click-counts
+	ClickCounts(ctx context.Context, filter *StatsFilter) (*ClickCounts, error)
+}
+
+type stats struct {
+	pm *postmark
+}
+
+var _ Stats = (*stats)(nil)
+
+// StatsFilter defines the criteria stats can be restricted to. Zero values are ignored, and only
+// the date part of FromDate and ToDate is used.
+type StatsFilter struct {
+	Tag      string
+	FromDate time.Time
+	ToDate   time.Time
+}
+
+func (f *StatsFilter) values() url.Values {
+	params := url.Values{}
+	if f == nil {
+		return params
+	}
+	setParam(params, "tag", f.Tag)
+	if !f.FromDate.IsZero() {
+		params.Set("fromdate", f.FromDate.Format(dateOnly))
+	}
+	if !f.ToDate.IsZero() {
+		params.Set("todate", f.ToDate.Format(dateOnly))
+	}
+	return params
+}
+
+// get retrieves the stats at the given path below "stats/outbound"
+func (s *stats) get(ctx context.Context, filter *StatsFilter, target interface{}, elem ...string) error {
+	_, err := s.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join(append([]string{"stats", "outbound"}, elem...)...),
+		Params: filter.values(),
+		Target: target,
+	})
+	return err
+}
+
+// OutboundOverview defines a summary of outbound statistics
+type OutboundOverview struct {
+	Sent                  int64
+	Bounced               int64
+	SMTPAPIErrors         int64 `json:"SMTPApiErrors"`
+	BounceRate            float64
+	SpamComplaints        int64
+	SpamComplaintsRate    float64
+	Opens                 int64
+	UniqueOpens           int64
+	Tracked               int64
+	WithClientRecorded    int64
+	WithPlatformRecorded  int64
+	WithReadTimeRecorded  int64
+	TotalClicks           int64
+	UniqueLinksClicked    int64
+	TotalTrackedLinksSent int64
+	WithLinkTracking      int64
+	WithOpenTracking      int64
+}
+
+func (s *stats) Overview(ctx context.Context, filter *StatsFilter) (*OutboundOverview, error) {
+	overview := new(OutboundOverview)
+	if err := s.get(ctx, filter, overview); err != nil {
+		return nil, err
+	}
+	return overview, nil
+}
+
+// SentCounts defines the number of sent emails
+type SentCounts struct {
+	Days []DailySentCounts
+	Sent int64
+}
+
+// DailySentCounts defines the number of sent emails on a single day
+type DailySentCounts struct {
+	Date Time
+	Sent int64
+}
+
+func (s *stats) SentCounts(ctx context.Context, filter *StatsFilter) (*SentCounts, error) {
+	counts := new(SentCounts)
+	if err := s.get(ctx, filter, counts, "sends"); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// BounceCounts defines the number of bounces by type
+type BounceCounts struct {
+	Days []DailyBounceCounts
+	BounceTypeCounts
+}
+
+// DailyBounceCounts defines the number of bounces by type on a single day
+type DailyBounceCounts struct {
+	Date Time
+	BounceTypeCounts
+}
+
+// BounceTypeCounts defines the number of bounces of each type counted by the stats API
+type BounceTypeCounts struct {
+	HardBounce   int64
+	SoftBounce   int64
+	SMTPAPIError int64 `json:"SMTPApiError"`
+	Transient    int64
+}
+
+func (s *stats) BounceCounts(ctx context.Context, filter *StatsFilter) (*BounceCounts, error) {
+	counts := new(BounceCounts)
+	if err := s.get(ctx, filter, counts, "bounces"); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// SpamComplaintCounts defines the number of spam complaints
+type SpamComplaintCounts struct {
+	Days          []DailySpamComplaintCounts
+	SpamComplaint int64
+}
+
+// DailySpamComplaintCounts defines the number of spam complaints on a single day
+type DailySpamComplaintCounts struct {
+	Date          Time
+	SpamComplaint int64
+}
+
+func (s *stats) SpamComplaints(ctx context.Context, filter *StatsFilter) (*SpamComplaintCounts, error) {
+	counts := new(SpamComplaintCounts)
+	if err := s.get(ctx, filter, counts, "spam"); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// TrackedCounts defines the number of sent emails with open tracking enabled
+type TrackedCounts struct {
+	Days    []DailyTrackedCounts
+	Tracked int64
+}
+
+// DailyTrackedCounts defines the number of sent emails with open tracking on a single day
+type DailyTrackedCounts struct {
+	Date    Time
+	Tracked int64
+}
+
+func (s *stats) TrackedCounts(ctx context.Context, filter *StatsFilter) (*TrackedCounts, error) {
+	counts := new(TrackedCounts)
+	if err := s.get(ctx, filter, counts, "tracked"); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// OpenCounts defines the number of opens, and how many of them were the first open of an email
+type OpenCounts struct {
+	Days   []DailyOpenCounts
+	Opens  int64
+	Unique int64
+}
+
+// DailyOpenCounts defines the number of opens on a single day
+type DailyOpenCounts struct {
+	Date   Time
+	Opens  int64
+	Unique int64
+}
+
+func (s *stats) OpenCounts(ctx context.Context, filter *StatsFilter) (*OpenCounts, error) {
+	counts := new(OpenCounts)
+	if err := s.get(ctx, filter, counts, "opens"); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// PlatformUsage defines the number of opens by platform
+type PlatformUsage struct {
+	Days []DailyPlatformUsage
+	PlatformCounts
+}
+
+// DailyPlatformUsage defines the number of opens by platform on a single day
+type DailyPlatformUsage struct {
+	Date Time
+	PlatformCounts
+}
+
+// PlatformCounts defines the number of opens on each platform
+type PlatformCounts struct {
+	Desktop int64
+	WebMail int64
+	Mobile  int64
+	Unknown int64
+}
+
+func (s *stats) PlatformUsage(ctx context.Context, filter *StatsFilter) (*PlatformUsage, error) {
+	usage := new(PlatformUsage)
+	if err := s.get(ctx, filter, usage, "opens", "platforms"); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// NamedCounts defines stats whose categories are not known in advance, such as the names of email
+// clients. Categories without any events are left out by Postmark.
+type NamedCounts struct {
+	Days   []DailyNamedCounts
+	Totals map[string]int64
+}
+
+// DailyNamedCounts defines the counts of a NamedCounts for a single day
+type DailyNamedCounts struct {
+	Date   Time
+	Counts map[string]int64
+}
+
+// UnmarshalJSON collects the categories of the response, which are sent alongside "Days"
+func (c *NamedCounts) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if days, ok := fields["Days"]; ok {
+		if err := json.Unmarshal(days, &c.Days); err != nil {
+			return err
+		}
+		delete(fields, "Days")
+	}
+	return unmarshalCounts(fields, &c.Totals)
+}
+
+// UnmarshalJSON collects the categories of the day, which are sent alongside "Date"
+func (c *DailyNamedCounts) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if date, ok := fields["Date"]; ok {
+		if err := json.Unmarshal(date, &c.Date); err != nil {
+			return err
+		}
+		delete(fields, "Date")
+	}
+	return unmarshalCounts(fields, &c.Counts)
+}
+
+func unmarshalCounts(fields map[string]json.RawMessage, counts *map[string]int64) error {
+	*counts = make(map[string]int64, len(fields))
+	for name, raw := range fields {
+		var n int64
+		if err := json.Unmarshal(raw, &n); err != nil {
+			return err
+		}
+		(*counts)[name] = n
+	}
+	return nil
+}
+
+func (s *stats) EmailClientUsage(ctx context.Context, filter *StatsFilter) (*NamedCounts, error) {
+	usage := new(NamedCounts)
+	if err := s.get(ctx, filter, usage, "opens", "emailclients"); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+func (s *stats) ReadTimes(ctx context.Context, filter *StatsFilter) (*NamedCounts, error) {
+	times := new(NamedCounts)
+	if err := s.get(ctx, filter, times, "opens", "readtimes"); err != nil {
+		return nil, err
+	}
+	return times, nil
+}
+
+// ClickCounts defines the number of clicks, and how many of them were the first click of a link
+type ClickCounts struct {
+	Days   []DailyClickCounts
+	Clicks int64
+	Unique int64
+}
+
+// DailyClickCounts defines the number of clicks on a single day
+type DailyClickCounts struct {
+	Date   Time
+	Clicks int64
+	Unique int64
+}
+
+func (s *stats) ClickCounts(ctx context.Context, filter *StatsFilter) (*ClickCounts, error) {
+	counts := new(ClickCounts)
+	if err := s.get(ctx, filter, counts, "clicks"); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
@@ -0,0 +1,49 @@
+package postmark
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// from: http://developer.postmarkapp.com/developer-api-stats.html#email-client-usage
+const emailClientUsageExample = `
+{
+  "Days": [
+    {
+      "Date": "2014-01-01",
+      "Outlook 2010": 1,
+      "Apple Mail 7": 5
+    },
+    {
+      "Date": "2014-01-02",
+      "Apple Mail 7": 2
+    }
+  ],
+  "Outlook 2010": 1,
+  "Apple Mail 7": 7
+}
+`
+
+func TestNamedCountsUnmarshal(t *testing.T) {
+	var v NamedCounts
+	if err := json.Unmarshal([]byte(emailClientUsageExample), &v); err != nil {
+		t.Fatalf("error unmarshalling json: %v", err)
+	}
+
+	if len(v.Days) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(v.Days))
+	}
+	if date := time.Time(v.Days[1].Date); !date.Equal(time.Date(2014, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected date: %v", date)
+	}
+	if n := v.Days[0].Counts["Apple Mail 7"]; n != 5 {
+		t.Errorf("expected 5 opens with Apple Mail 7 on the first day, got %d", n)
+	}
+	if len(v.Days[1].Counts) != 1 {
+		t.Errorf("expected a single client on the second day, got %v", v.Days[1].Counts)
+	}
+	if n := v.Totals["Apple Mail 7"]; n != 7 {
+		t.Errorf("expected 7 opens with Apple Mail 7 in total, got %d", n)
+	}
+}
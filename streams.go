@@ -0,0 +1,194 @@
+package postmark
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// MessageStreams defines the functionality of the message streams resource
+type MessageStreams interface {
+	// List returns the message streams of the server. An empty streamType returns streams of all
+	// types.
+	// http://developer.postmarkapp.com/developer-api-streams.html#list-message-streams
+	List(ctx context.Context, streamType MessageStreamType, includeArchived bool) (*MessageStreamList, error)
+
+	// Get retrieves an individual message stream
+	// http://developer.postmarkapp.com/developer-api-streams.html#get-a-message-stream
+	Get(ctx context.Context, id string) (*MessageStream, error)
+
+	// Create creates a new message stream. Its ID and type cannot be changed later on.
+	// http://developer.postmarkapp.com/developer-api-streams.html#create-a-message-stream
+	Create(ctx context.Context, stream *MessageStreamReq) (*MessageStream, error)
+
+	// Edit modifies an existing message stream. The ID and MessageStreamType of the request are
+	// ignored.
+	// http://developer.postmarkapp.com/developer-api-streams.html#edit-a-message-stream
+	Edit(ctx context.Context, id string, stream *MessageStreamReq) (*MessageStream, error)
+
+	// Archive archives a message stream. Archived streams can no longer be sent to, and are
+	// deleted along with their data once their purge date has passed.
+	// http://developer.postmarkapp.com/developer-api-streams.html#archive-a-message-stream
+	Archive(ctx context.Context, id string) (*MessageStreamArchival, error)
+
+	// Unarchive restores an archived message stream before its purge date
+	// http://developer.postmarkapp.com/developer-api-streams.html#unarchive-a-message-stream
+	Unarchive(ctx context.Context, id string) (*MessageStream, error)
+}
+
+type messageStreams struct {
+	pm *postmark
+}
+
+var _ MessageStreams = (*messageStreams)(nil)
+
+// MessageStreamType defines types of message streams.
+type MessageStreamType string
+
+// MessageStreamType constant definitions.
+const (
+	MessageStreamTypeTransactional MessageStreamType = "Transactional"
+	MessageStreamTypeBroadcasts    MessageStreamType = "Broadcasts"
+	MessageStreamTypeInbound       MessageStreamType = "Inbound"
+)
+
+// MessageStream defines the message stream entities within postmark
+type MessageStream struct {
+	ID                string
+	ServerID          int64
+	Name              string
+	Description       string
+	MessageStreamType MessageStreamType
+	CreatedAt         time.Time
+	UpdatedAt         *time.Time
+	ArchivedAt        *time.Time
+	ExpectedPurgeDate *time.Time
+
+	SubscriptionManagementConfiguration SubscriptionManagementConfiguration
+}
+
+// SubscriptionManagementConfiguration defines how unsubscribes are handled for a message stream
+type SubscriptionManagementConfiguration struct {
+	// one of "None", "Postmark" or "Custom"
+	UnsubscribeHandlingType string
+}
+
+// MessageStreamReq defines the fields of a message stream that can be set when creating or editing
+// it
+type MessageStreamReq struct {
+	ID                string            `json:",omitempty"`
+	Name              string            `json:",omitempty"`
+	Description       string            `json:",omitempty"`
+	MessageStreamType MessageStreamType `json:",omitempty"`
+
+	SubscriptionManagementConfiguration *SubscriptionManagementConfiguration `json:",omitempty"`
+}
+
+// MessageStreamList defines a list of message stream entities
+type MessageStreamList struct {
+	TotalCount     int64
+	MessageStreams []*MessageStream
+}
+
+func (m *messageStreams) List(ctx context.Context, streamType MessageStreamType, includeArchived bool) (*MessageStreamList, error) {
+	if streamType == "" {
+		streamType = "All"
+	}
+
+	streamList := new(MessageStreamList)
+	_, err := m.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   "message-streams",
+		Params: url.Values{
+			"MessageStreamType":      {string(streamType)},
+			"IncludeArchivedStreams": {strconv.FormatBool(includeArchived)},
+		},
+		Target: streamList,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return streamList, nil
+}
+
+func (m *messageStreams) Get(ctx context.Context, id string) (*MessageStream, error) {
+	stream := new(MessageStream)
+	_, err := m.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("message-streams", id),
+		Target: stream,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (m *messageStreams) Create(ctx context.Context, req *MessageStreamReq) (*MessageStream, error) {
+	stream := new(MessageStream)
+	_, err := m.pm.Exec(ctx, &Request{
+		Method:  "POST",
+		Path:    "message-streams",
+		Payload: req,
+		Target:  stream,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (m *messageStreams) Edit(ctx context.Context, id string, req *MessageStreamReq) (*MessageStream, error) {
+	// the ID and type of a stream cannot be changed, so they are not part of an edit
+	edit := *req
+	edit.ID = ""
+	edit.MessageStreamType = ""
+
+	stream := new(MessageStream)
+	_, err := m.pm.Exec(ctx, &Request{
+		Method:  "PATCH",
+		Path:    path.Join("message-streams", id),
+		Payload: &edit,
+		Target:  stream,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// MessageStreamArchival defines the response after a message stream is archived
+type MessageStreamArchival struct {
+	ID                string
+	ServerID          int64
+	ExpectedPurgeDate time.Time
+}
+
+func (m *messageStreams) Archive(ctx context.Context, id string) (*MessageStreamArchival, error) {
+	archival := new(MessageStreamArchival)
+	_, err := m.pm.Exec(ctx, &Request{
+		Method: "POST",
+		Path:   path.Join("message-streams", id, "archive"),
+		Target: archival,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return archival, nil
+}
+
+func (m *messageStreams) Unarchive(ctx context.Context, id string) (*MessageStream, error) {
+	stream := new(MessageStream)
+	_, err := m.pm.Exec(ctx, &Request{
+		Method: "POST",
+		Path:   path.Join("message-streams", id, "unarchive"),
+		Target: stream,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
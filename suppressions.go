@@ -0,0 +1,135 @@
+package postmark
+
+import (
+	"net/url"
+	"path"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Suppressions defines the functionality of the suppressions resource. Suppressed addresses are
+// not sent to through the given message stream.
+type Suppressions interface {
+	// Dump returns the suppressed addresses of a message stream matching the given filter, which
+	// may be nil
+	// http://developer.postmarkapp.com/developer-api-suppressions.html#suppression-dump
+	Dump(ctx context.Context, streamID string, filter *SuppressionFilter) ([]*Suppression, error)
+
+	// Create suppresses the given addresses. Lists longer than MaxSuppressionsPerRequest are
+	// split into several calls. The result of each address is reported in the same order as
+	// the given addresses.
+	// http://developer.postmarkapp.com/developer-api-suppressions.html#create-a-suppression
+	Create(ctx context.Context, streamID string, emails []string) ([]*SuppressionResult, error)
+
+	// Delete reactivates the given addresses, the same way as Create. Addresses suppressed
+	// because of a spam complaint cannot be reactivated.
+	// http://developer.postmarkapp.com/developer-api-suppressions.html#delete-a-suppression
+	Delete(ctx context.Context, streamID string, emails []string) ([]*SuppressionResult, error)
+}
+
+// MaxSuppressionsPerRequest is the maximum number of addresses Postmark accepts in a single call
+// creating or deleting suppressions.
+const MaxSuppressionsPerRequest = 50
+
+type suppressions struct {
+	pm *postmark
+}
+
+var _ Suppressions = (*suppressions)(nil)
+
+// Suppression defines a suppressed address within postmark
+type Suppression struct {
+	EmailAddress string
+	// one of "HardBounce", "SpamComplaint" or "ManualSuppression"
+	SuppressionReason string
+	// one of "Recipient", "Customer" or "Admin"
+	Origin    string
+	CreatedAt time.Time
+}
+
+// SuppressionFilter defines the criteria suppressions can be dumped by. Zero values are ignored.
+type SuppressionFilter struct {
+	SuppressionReason string
+	Origin            string
+	EmailAddress      string
+	FromDate          time.Time
+	ToDate            time.Time
+}
+
+func (f *SuppressionFilter) values() url.Values {
+	params := url.Values{}
+	if f == nil {
+		return params
+	}
+	setParam(params, "SuppressionReason", f.SuppressionReason)
+	setParam(params, "Origin", f.Origin)
+	setParam(params, "EmailAddress", f.EmailAddress)
+	if !f.FromDate.IsZero() {
+		params.Set("fromdate", f.FromDate.Format(dateOnly))
+	}
+	if !f.ToDate.IsZero() {
+		params.Set("todate", f.ToDate.Format(dateOnly))
+	}
+	return params
+}
+
+func (s *suppressions) Dump(ctx context.Context, streamID string, filter *SuppressionFilter) ([]*Suppression, error) {
+	var dump struct{ Suppressions []*Suppression }
+	_, err := s.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("message-streams", streamID, "suppressions", "dump"),
+		Params: filter.values(),
+		Target: &dump,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dump.Suppressions, nil
+}
+
+// SuppressionResult defines the outcome of creating or deleting the suppression of an address
+type SuppressionResult struct {
+	EmailAddress string
+	// one of "Suppressed", "Deleted" or "Failed"
+	Status string
+	// explains why the address failed
+	Message string
+}
+
+type suppressionAddress struct {
+	EmailAddress string
+}
+
+// modify creates or deletes the suppressions of the given addresses
+func (s *suppressions) modify(ctx context.Context, streamID string, emails []string, elem ...string) ([]*SuppressionResult, error) {
+	results := make([]*SuppressionResult, 0, len(emails))
+	err := forEachBatch(len(emails), MaxSuppressionsPerRequest, func(start, end int) error {
+		var payload struct{ Suppressions []suppressionAddress }
+		for _, email := range emails[start:end] {
+			payload.Suppressions = append(payload.Suppressions, suppressionAddress{EmailAddress: email})
+		}
+
+		var chunk struct{ Suppressions []*SuppressionResult }
+		_, err := s.pm.Exec(ctx, &Request{
+			Method:  "POST",
+			Path:    path.Join(append([]string{"message-streams", streamID, "suppressions"}, elem...)...),
+			Payload: &payload,
+			Target:  &chunk,
+		})
+		results = append(results, chunk.Suppressions...)
+		return err
+	})
+	if err != nil {
+		return results, err
+	}
+	return results, nil
+}
+
+func (s *suppressions) Create(ctx context.Context, streamID string, emails []string) ([]*SuppressionResult, error) {
+	return s.modify(ctx, streamID, emails)
+}
+
+func (s *suppressions) Delete(ctx context.Context, streamID string, emails []string) ([]*SuppressionResult, error) {
+	return s.modify(ctx, streamID, emails, "delete")
+}
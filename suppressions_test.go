@@ -0,0 +1,53 @@
+package postmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestSuppressionsChunking(t *testing.T) {
+	var sizes []int
+	p := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/message-streams/outbound/suppressions/delete" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		var payload struct{ Suppressions []suppressionAddress }
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("error decoding suppressions: %v", err)
+		}
+		sizes = append(sizes, len(payload.Suppressions))
+
+		var resp struct{ Suppressions []*SuppressionResult }
+		for _, s := range payload.Suppressions {
+			resp.Suppressions = append(resp.Suppressions, &SuppressionResult{EmailAddress: s.EmailAddress, Status: "Deleted"})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&resp)
+	})
+
+	emails := make([]string, 2*MaxSuppressionsPerRequest+20)
+	for i := range emails {
+		emails[i] = fmt.Sprintf("%d@example.com", i)
+	}
+
+	results, err := p.Suppressions().Delete(context.Background(), "outbound", emails)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sizes) != 3 || sizes[0] != MaxSuppressionsPerRequest || sizes[2] != 20 {
+		t.Errorf("unexpected chunk sizes: %v", sizes)
+	}
+	if len(results) != len(emails) {
+		t.Fatalf("expected %d results, got %d", len(emails), len(results))
+	}
+	for i, result := range results {
+		if result.EmailAddress != emails[i] {
+			t.Errorf("result %d is for %q, expected %q", i, result.EmailAddress, emails[i])
+			break
+		}
+	}
+}
@@ -38,6 +38,11 @@ type Templates interface {
 	// method that lives on the `Emails` resource, but lives here in order to match the Postmark docs.
 	// http://developer.postmarkapp.com/developer-api-templates.html#email-with-template
 	Email(ctx context.Context, email *EmailWithTemplate) (*EmailResponse, error)
+
+	// EmailBatch sends many emails with their given templates. This is a wrapper around the
+	// `BatchWithTemplate` method that lives on the `Emails` resource.
+	// http://developer.postmarkapp.com/developer-api-templates.html#send-batch-with-templates
+	EmailBatch(ctx context.Context, emails []*EmailWithTemplate) ([]*EmailResponse, error)
 }
 
 type templates struct {
@@ -195,6 +200,10 @@ func (t *templates) Email(ctx context.Context, email *EmailWithTemplate) (*Email
 	return t.pm.Emails().EmailWithTemplate(ctx, email)
 }
 
+func (t *templates) EmailBatch(ctx context.Context, emails []*EmailWithTemplate) ([]*EmailResponse, error) {
+	return t.pm.Emails().BatchWithTemplate(ctx, emails)
+}
+
 // helpers
 
 // cleans up syntax for putting ids into URLs
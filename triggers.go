@@ -0,0 +1,196 @@
+package postmark
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+
+	"golang.org/x/net/context"
+)
+
+// Triggers defines the functionality of the triggers resource
+type Triggers interface {
+	// ListTagTriggers returns the tag triggers of the server. If matchName is not empty, only
+	// triggers whose name contains it are returned.
+	// http://developer.postmarkapp.com/developer-api-triggers.html#search-tag-triggers
+	ListTagTriggers(ctx context.Context, count, offset int, matchName string) (*TagTriggerList, error)
+
+	// GetTagTrigger retrieves an individual tag trigger
+	// http://developer.postmarkapp.com/developer-api-triggers.html#get-a-single-tag-trigger
+	GetTagTrigger(ctx context.Context, id int64) (*TagTrigger, error)
+
+	// CreateTagTrigger creates a new tag trigger. A tag can only have a single trigger, further
+	// ones are rejected with ErrTagTriggerExists.
+	// http://developer.postmarkapp.com/developer-api-triggers.html#create-a-trigger-for-a-tag
+	CreateTagTrigger(ctx context.Context, trigger *TagTrigger) (*TagTrigger, error)
+
+	// EditTagTrigger modifies an existing tag trigger
+	// http://developer.postmarkapp.com/developer-api-triggers.html#edit-a-single-tag-trigger
+	EditTagTrigger(ctx context.Context, id int64, trigger *TagTrigger) (*TagTrigger, error)
+
+	// DeleteTagTrigger permanently deletes a tag trigger
+	// http://developer.postmarkapp.com/developer-api-triggers.html#delete-a-single-tag-trigger
+	DeleteTagTrigger(ctx context.Context, id int64) (*StatusResp, error)
+
+	// ListInboundRules returns the inbound rule triggers of the server
+	// http://developer.postmarkapp.com/developer-api-triggers.html#list-inbound-rule-triggers
+	ListInboundRules(ctx context.Context, count, offset int) (*InboundRuleList, error)
+
+	// CreateInboundRule blocks inbound messages from the given email address or domain
+	// http://developer.postmarkapp.com/developer-api-triggers.html#create-an-inbound-rule-trigger
+	CreateInboundRule(ctx context.Context, rule string) (*InboundRule, error)
+
+	// DeleteInboundRule permanently deletes an inbound rule trigger
+	// http://developer.postmarkapp.com/developer-api-triggers.html#delete-a-single-trigger
+	DeleteInboundRule(ctx context.Context, id int64) (*StatusResp, error)
+}
+
+type triggers struct {
+	pm *postmark
+}
+
+var _ Triggers = (*triggers)(nil)
+
+// TagTrigger defines the tag trigger entities within postmark
+type TagTrigger struct {
+	ID         int64 `json:",omitempty"`
+	MatchName  string
+	TrackOpens bool
+}
+
+// TagTriggerList defines a list of tag trigger entities
+type TagTriggerList struct {
+	TotalCount int64
+	Tags       []*TagTrigger
+}
+
+func (t *triggers) ListTagTriggers(ctx context.Context, count, offset int, matchName string) (*TagTriggerList, error) {
+	params := url.Values{
+		"count":  {strconv.Itoa(count)},
+		"offset": {strconv.Itoa(offset)},
+	}
+	setParam(params, "match_name", matchName)
+
+	triggerList := new(TagTriggerList)
+	_, err := t.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("triggers", "tags"),
+		Params: params,
+		Target: triggerList,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return triggerList, nil
+}
+
+func (t *triggers) GetTagTrigger(ctx context.Context, id int64) (*TagTrigger, error) {
+	trigger := new(TagTrigger)
+	_, err := t.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("triggers", "tags", i64toa(id)),
+		Target: trigger,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return trigger, nil
+}
+
+func (t *triggers) CreateTagTrigger(ctx context.Context, trigger *TagTrigger) (*TagTrigger, error) {
+	created := new(TagTrigger)
+	_, err := t.pm.Exec(ctx, &Request{
+		Method:  "POST",
+		Path:    path.Join("triggers", "tags"),
+		Payload: trigger,
+		Target:  created,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (t *triggers) EditTagTrigger(ctx context.Context, id int64, trigger *TagTrigger) (*TagTrigger, error) {
+	edited := new(TagTrigger)
+	_, err := t.pm.Exec(ctx, &Request{
+		Method:  "PUT",
+		Path:    path.Join("triggers", "tags", i64toa(id)),
+		Payload: trigger,
+		Target:  edited,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return edited, nil
+}
+
+func (t *triggers) DeleteTagTrigger(ctx context.Context, id int64) (*StatusResp, error) {
+	resp := new(StatusResp)
+	_, err := t.pm.Exec(ctx, &Request{
+		Method: "DELETE",
+		Path:   path.Join("triggers", "tags", i64toa(id)),
+		Target: resp,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// InboundRule defines the inbound rule trigger entities within postmark
+type InboundRule struct {
+	ID int64 `json:",omitempty"`
+	// the email address or domain that is blocked
+	Rule string
+}
+
+// InboundRuleList defines a list of inbound rule trigger entities
+type InboundRuleList struct {
+	TotalCount   int64
+	InboundRules []*InboundRule
+}
+
+func (t *triggers) ListInboundRules(ctx context.Context, count, offset int) (*InboundRuleList, error) {
+	ruleList := new(InboundRuleList)
+	_, err := t.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("triggers", "inboundrules"),
+		Params: url.Values{
+			"count":  {strconv.Itoa(count)},
+			"offset": {strconv.Itoa(offset)},
+		},
+		Target: ruleList,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ruleList, nil
+}
+
+func (t *triggers) CreateInboundRule(ctx context.Context, rule string) (*InboundRule, error) {
+	created := new(InboundRule)
+	_, err := t.pm.Exec(ctx, &Request{
+		Method:  "POST",
+		Path:    path.Join("triggers", "inboundrules"),
+		Payload: &InboundRule{Rule: rule},
+		Target:  created,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (t *triggers) DeleteInboundRule(ctx context.Context, id int64) (*StatusResp, error) {
+	resp := new(StatusResp)
+	_, err := t.pm.Exec(ctx, &Request{
+		Method: "DELETE",
+		Path:   path.Join("triggers", "inboundrules", i64toa(id)),
+		Target: resp,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,134 @@
+package postmark
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// PostmarkWebhookCIDRs lists the networks Postmark sends webhooks from. It must be kept in sync
+// with the addresses Postmark publishes, which may change:
+// https://postmarkapp.com/support/article/800-ips-for-firewalls#webhooks
+var PostmarkWebhookCIDRs = []string{
+	"3.134.147.250/32",
+	"50.31.156.6/32",
+	"50.31.156.77/32",
+	"18.217.206.57/32",
+}
+
+// WebhookAuth verifies that webhook requests come from Postmark before passing them on. It checks
+// the basic auth credentials configured for the webhook and, optionally, the address the request
+// was sent from.
+type WebhookAuth struct {
+	// Username and Password are the credentials of the webhook. Requests are not checked for
+	// credentials if both are empty.
+	Username string
+	Password string
+
+	// AllowedNetworks restricts the addresses requests are accepted from, use ParseCIDRs with
+	// PostmarkWebhookCIDRs to only accept requests from Postmark. All addresses are accepted if
+	// it is empty.
+	AllowedNetworks []*net.IPNet
+
+	// TrustedProxies is the number of reverse proxies in front of the handler, each of which
+	// appends the address it received the request from to X-Forwarded-For. If it is zero, the
+	// header is ignored and the remote address of the connection is checked instead.
+	TrustedProxies int
+
+	// RejectStatus is the status returned to requests from addresses that are not allowed. It
+	// defaults to 401, which Postmark retries, so that no webhook is lost if its addresses change
+	// before AllowedNetworks is updated. Postmark stops retrying on a 403.
+	RejectStatus int
+}
+
+// ParseCIDRs parses a list of networks in CIDR notation, such as PostmarkWebhookCIDRs.
+func ParseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, ipnet)
+	}
+	return nets, nil
+}
+
+// Wrap returns an http.Handler passing authenticated requests to h. Requests from addresses that
+// are not allowed are rejected with RejectStatus, and requests with wrong credentials with a 401.
+func (a *WebhookAuth) Wrap(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(a.AllowedNetworks) > 0 {
+			ip, err := a.clientIP(r)
+			if err != nil || !a.allowed(ip) {
+				status := a.RejectStatus
+				if status == 0 {
+					status = http.StatusUnauthorized
+				}
+				http.Error(w, http.StatusText(status), status)
+				return
+			}
+		}
+
+		if a.Username != "" || a.Password != "" {
+			username, password, _ := r.BasicAuth()
+			if !a.validCredentials(username, password) {
+				w.Header().Set("WWW-Authenticate", `Basic realm="postmark"`)
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// validCredentials compares credentials in constant time. Hashing them first keeps the comparison
+// from revealing their length.
+func (a *WebhookAuth) validCredentials(username, password string) bool {
+	gotUser, wantUser := sha256.Sum256([]byte(username)), sha256.Sum256([]byte(a.Username))
+	gotPass, wantPass := sha256.Sum256([]byte(password)), sha256.Sum256([]byte(a.Password))
+
+	userOK := subtle.ConstantTimeCompare(gotUser[:], wantUser[:])
+	passOK := subtle.ConstantTimeCompare(gotPass[:], wantPass[:])
+	return userOK&passOK == 1
+}
+
+// clientIP returns the address the request was sent from, taking trusted proxies into account
+func (a *WebhookAuth) clientIP(r *http.Request) (net.IP, error) {
+	addr := r.RemoteAddr
+	if a.TrustedProxies > 0 {
+		var hops []string
+		for _, header := range r.Header["X-Forwarded-For"] {
+			for _, hop := range strings.Split(header, ",") {
+				hops = append(hops, strings.TrimSpace(hop))
+			}
+		}
+		// entries before the ones added by trusted proxies may have been forged by the client
+		if len(hops) < a.TrustedProxies {
+			return nil, fmt.Errorf("postmark: expected %d proxies in X-Forwarded-For, got %d", a.TrustedProxies, len(hops))
+		}
+		addr = hops[len(hops)-a.TrustedProxies]
+	}
+
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return nil, fmt.Errorf("postmark: invalid client address %q", addr)
+	}
+	return ip, nil
+}
+
+func (a *WebhookAuth) allowed(ip net.IP) bool {
+	for _, ipnet := range a.AllowedNetworks {
+		if ipnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
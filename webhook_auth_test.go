@@ -0,0 +1,58 @@
+package postmark
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookAuth(t *testing.T) {
+	nets, err := ParseCIDRs(PostmarkWebhookCIDRs)
+	if err != nil {
+		t.Fatalf("error parsing webhook networks: %v", err)
+	}
+
+	auth := &WebhookAuth{
+		Username:        "postmark",
+		Password:        "secret",
+		AllowedNetworks: nets,
+		TrustedProxies:  1,
+	}
+	h := auth.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, test := range []struct {
+		name         string
+		forwardedFor []string
+		user, pass   string
+		expectedCode int
+	}{
+		{"valid", []string{"50.31.156.6"}, "postmark", "secret", http.StatusOK},
+		{"wrong password", []string{"50.31.156.6"}, "postmark", "guess", http.StatusUnauthorized},
+		{"no credentials", []string{"50.31.156.6"}, "", "", http.StatusUnauthorized},
+		{"unknown address", []string{"192.0.2.1"}, "postmark", "secret", http.StatusUnauthorized},
+		{"forged address", []string{"50.31.156.6, 192.0.2.1"}, "postmark", "secret", http.StatusUnauthorized},
+		{"header sent by client", []string{"192.0.2.1", "50.31.156.6"}, "postmark", "secret", http.StatusOK},
+		{"no proxy", nil, "postmark", "secret", http.StatusUnauthorized},
+	} {
+		r := httptest.NewRequest("POST", "/webhook", nil)
+		r.Header["X-Forwarded-For"] = test.forwardedFor
+		if test.user != "" {
+			r.SetBasicAuth(test.user, test.pass)
+		}
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		if rec.Code != test.expectedCode {
+			t.Errorf("%s: expected status %d, got %d", test.name, test.expectedCode, rec.Code)
+		}
+	}
+
+	auth.RejectStatus = http.StatusForbidden
+	r := httptest.NewRequest("POST", "/webhook", nil)
+	r.SetBasicAuth("postmark", "secret")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected status %d with RejectStatus set, got %d", http.StatusForbidden, rec.Code)
+	}
+}
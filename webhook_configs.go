@@ -0,0 +1,159 @@
+package postmark
+
+import (
+	"net/url"
+	"path"
+
+	"golang.org/x/net/context"
+)
+
+// Webhooks defines the functionality of the webhooks resource, which manages where and when
+// Postmark sends webhooks. The payloads of the webhooks themselves are defined in webhooks.go.
+type Webhooks interface {
+	// List returns the webhook configurations of the server. If messageStream is not empty, only
+	// the webhooks of that stream are returned.
+	// http://developer.postmarkapp.com/developer-api-webhooks.html#list-webhooks
+	List(ctx context.Context, messageStream string) ([]*Webhook, error)
+
+	// Get retrieves an individual webhook configuration
+	// http://developer.postmarkapp.com/developer-api-webhooks.html#get-a-webhook
+	Get(ctx context.Context, id int64) (*Webhook, error)
+
+	// Create creates a new webhook configuration
+	// http://developer.postmarkapp.com/developer-api-webhooks.html#create-a-webhook
+	Create(ctx context.Context, webhook *Webhook) (*Webhook, error)
+
+	// Edit modifies an existing webhook configuration. Only the fields that are set are changed,
+	// except for the triggers of a given event, which are replaced as a whole.
+	// http://developer.postmarkapp.com/developer-api-webhooks.html#edit-a-webhook
+	Edit(ctx context.Context, id int64, webhook *Webhook) (*Webhook, error)
+
+	// Delete permanently deletes a webhook configuration
+	// http://developer.postmarkapp.com/developer-api-webhooks.html#delete-a-webhook
+	Delete(ctx context.Context, id int64) (*StatusResp, error)
+}
+
+type webhooks struct {
+	pm *postmark
+}
+
+var _ Webhooks = (*webhooks)(nil)
+
+// Webhook defines the webhook configuration entities within postmark. Pointer fields are optional
+// when editing a webhook, so that for example custom headers can be removed by setting
+// HTTPHeaders to an empty list.
+type Webhook struct {
+	ID            int64            `json:",omitempty"`
+	URL           string           `json:"Url,omitempty"`
+	MessageStream string           `json:",omitempty"`
+	HTTPAuth      *WebhookHTTPAuth `json:"HttpAuth,omitempty"`
+	HTTPHeaders   *[]Header        `json:"HttpHeaders,omitempty"`
+	Triggers      *WebhookTriggers `json:",omitempty"`
+}
+
+// WebhookHTTPAuth defines the basic auth credentials Postmark sends webhooks with
+type WebhookHTTPAuth struct {
+	Username string
+	Password string
+}
+
+// WebhookTriggers defines the events a webhook is sent for. Events that are nil are left unchanged
+// when editing a webhook.
+type WebhookTriggers struct {
+	Open               *WebhookOpenTrigger    `json:",omitempty"`
+	Click              *WebhookTrigger        `json:",omitempty"`
+	Delivery           *WebhookTrigger        `json:",omitempty"`
+	Bounce             *WebhookContentTrigger `json:",omitempty"`
+	SpamComplaint      *WebhookContentTrigger `json:",omitempty"`
+	SubscriptionChange *WebhookTrigger        `json:",omitempty"`
+}
+
+// WebhookTrigger defines whether a webhook is sent for an event
+type WebhookTrigger struct {
+	Enabled bool
+}
+
+// WebhookOpenTrigger defines whether a webhook is sent for opens
+type WebhookOpenTrigger struct {
+	Enabled bool
+	// only send a webhook for the first open of each message
+	PostFirstOpenOnly bool
+}
+
+// WebhookContentTrigger defines whether a webhook is sent for bounces or spam complaints
+type WebhookContentTrigger struct {
+	Enabled bool
+	// include the full content of the bounce or complaint in the webhook
+	IncludeContent bool
+}
+
+func (w *webhooks) List(ctx context.Context, messageStream string) ([]*Webhook, error) {
+	params := url.Values{}
+	setParam(params, "MessageStream", messageStream)
+
+	var webhookList struct{ Webhooks []*Webhook }
+	_, err := w.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   "webhooks",
+		Params: params,
+		Target: &webhookList,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return webhookList.Webhooks, nil
+}
+
+func (w *webhooks) Get(ctx context.Context, id int64) (*Webhook, error) {
+	webhook := new(Webhook)
+	_, err := w.pm.Exec(ctx, &Request{
+		Method: "GET",
+		Path:   path.Join("webhooks", i64toa(id)),
+		Target: webhook,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (w *webhooks) Create(ctx context.Context, webhook *Webhook) (*Webhook, error) {
+	created := new(Webhook)
+	_, err := w.pm.Exec(ctx, &Request{
+		Method:  "POST",
+		Path:    "webhooks",
+		Payload: webhook,
+		Target:  created,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (w *webhooks) Edit(ctx context.Context, id int64, webhook *Webhook) (*Webhook, error) {
+	edited := new(Webhook)
+	_, err := w.pm.Exec(ctx, &Request{
+		Method:  "PUT",
+		Path:    path.Join("webhooks", i64toa(id)),
+		Payload: webhook,
+		Target:  edited,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return edited, nil
+}
+
+func (w *webhooks) Delete(ctx context.Context, id int64) (*StatusResp, error) {
+	resp := new(StatusResp)
+	_, err := w.pm.Exec(ctx, &Request{
+		Method: "DELETE",
+		Path:   path.Join("webhooks", i64toa(id)),
+		Target: resp,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
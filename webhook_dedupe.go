@@ -0,0 +1,153 @@
+package postmark
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// DefaultDedupeTTL is the default time a webhook event is remembered by a WebhookHandler. It is
+// longer than the whole schedule of retries Postmark makes for a webhook.
+const DefaultDedupeTTL = 24 * time.Hour
+
+// DedupeStore records which webhook events a WebhookHandler has processed, so that events Postmark
+// sends more than once are only passed to the callbacks once. Implementations shared between
+// several processes, for example backed by Redis, deduplicate events across all of them.
+type DedupeStore interface {
+	// Claim records that the event identified by key is being processed. It returns false if
+	// the key has already been claimed within the last ttl.
+	Claim(ctx context.Context, key string, ttl time.Duration) (bool, error)
+
+	// Release forgets the key of an event that failed to be processed, so that it is processed
+	// again when Postmark retries it.
+	Release(ctx context.Context, key string) error
+}
+
+// MemoryDedupeStore is a DedupeStore keeping keys in memory, which only deduplicates events
+// received by a single process. Its zero value is ready to use.
+type MemoryDedupeStore struct {
+	mu        sync.Mutex
+	expiries  map[string]time.Time
+	nextSweep time.Time
+
+	// now is replaced in tests, time.Now is used if it is nil
+	now func() time.Time
+}
+
+var _ DedupeStore = (*MemoryDedupeStore)(nil)
+
+// NewMemoryDedupeStore returns an empty MemoryDedupeStore.
+func NewMemoryDedupeStore() *MemoryDedupeStore {
+	return &MemoryDedupeStore{}
+}
+
+// Claim implements DedupeStore.
+func (s *MemoryDedupeStore) Claim(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.now != nil {
+		now = s.now()
+	}
+	if s.expiries == nil {
+		s.expiries = make(map[string]time.Time)
+	}
+	s.sweep(now, ttl)
+
+	if expiry, ok := s.expiries[key]; ok && now.Before(expiry) {
+		return false, nil
+	}
+	s.expiries[key] = now.Add(ttl)
+	return true, nil
+}
+
+// Release implements DedupeStore.
+func (s *MemoryDedupeStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.expiries, key)
+	return nil
+}
+
+// sweep drops expired keys, at most once per ttl so that claims stay cheap
+func (s *MemoryDedupeStore) sweep(now time.Time, ttl time.Duration) {
+	if now.Before(s.nextSweep) {
+		return
+	}
+	for key, expiry := range s.expiries {
+		if !now.Before(expiry) {
+			delete(s.expiries, key)
+		}
+	}
+	s.nextSweep = now.Add(ttl)
+}
+
+// dedupe passes an event to fn unless it has already been processed
+func (h *WebhookHandler) dedupe(ctx context.Context, key string, fn func() error) error {
+	if h.Dedupe == nil {
+		return fn()
+	}
+
+	ttl := h.DedupeTTL
+	if ttl <= 0 {
+		ttl = DefaultDedupeTTL
+	}
+
+	fresh, err := h.Dedupe.Claim(ctx, key, ttl)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return nil
+	}
+
+	if err := fn(); err != nil {
+		// the callback error is what matters to Postmark, a failed release only means that the
+		// retry is dropped as a duplicate
+		h.Dedupe.Release(ctx, key)
+		return err
+	}
+	return nil
+}
+
+// dedupeKey joins the parts of the natural identity of an event
+func dedupeKey(recordType RecordType, parts ...string) string {
+	return string(recordType) + "/" + strings.Join(parts, "/")
+}
+
+func formatKeyTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func (w *BounceWebhook) dedupeKey() string {
+	return dedupeKey(RecordTypeBounce, strconv.FormatInt(w.ID, 10))
+}
+
+func (w *SpamComplaintWebhook) dedupeKey() string {
+	return dedupeKey(RecordTypeSpamComplaint, strconv.FormatInt(w.ID, 10))
+}
+
+func (w *DeliveryWebhook) dedupeKey() string {
+	return dedupeKey(RecordTypeDelivery, w.MessageID, w.Recipient, formatKeyTime(w.DeliveredAt))
+}
+
+func (w *OpenWebhook) dedupeKey() string {
+	return dedupeKey(RecordTypeOpen, w.MessageID, w.Recipient, formatKeyTime(time.Time(w.ReceivedAt)))
+}
+
+func (w *ClickWebhook) dedupeKey() string {
+	return dedupeKey(RecordTypeClick, w.MessageID, w.Recipient, formatKeyTime(time.Time(w.ReceivedAt)), w.OriginalLink)
+}
+
+func (w *SubscriptionChangeWebhook) dedupeKey() string {
+	return dedupeKey(RecordTypeSubscriptionChange, w.MessageStream, w.Recipient, formatKeyTime(w.ChangedAt))
+}
+
+func (w *InboundWebhook) dedupeKey() string {
+	return dedupeKey(RecordTypeInbound, w.MessageID)
+}
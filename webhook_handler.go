@@ -0,0 +1,204 @@
+package postmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// DefaultWebhookMaxBodyBytes is the default limit on the size of webhook requests. It leaves room
+// for inbound webhooks, which carry attachments of up to 35MB encoded as base64.
+const DefaultWebhookMaxBodyBytes = 50 << 20
+
+// WebhookHandler is an http.Handler receiving Postmark webhooks. It decodes each request according
+// to its RecordType and passes it to the callback registered for that type.
+//
+// Requests are acknowledged with a 200 once the callback succeeds, or right away if the event is a
+// duplicate detected through Dedupe. Callback errors are answered with a 500 so that Postmark
+// retries the webhook later, and malformed requests with a 4xx. Record types without a callback
+// are acknowledged and dropped.
+// http://developer.postmarkapp.com/developer-webhooks-overview.html
+type WebhookHandler struct {
+	// MaxBodyBytes limits the size of a request, DefaultWebhookMaxBodyBytes is used if it is zero.
+	MaxBodyBytes int64
+
+	// HandleError, if set, is called with the reason of every request that is not acknowledged.
+	HandleError func(r *http.Request, err error)
+
+	// Dedupe, if set, is used to pass every event to the callbacks at most once within
+	// DedupeTTL, even though Postmark may send it again. Events are identified by their ID if
+	// they have one, and otherwise by their message, recipient and timestamp. Events whose
+	// callback fails are forgotten so that Postmark's retry is processed.
+	Dedupe DedupeStore
+	// DedupeTTL is how long events are remembered, DefaultDedupeTTL is used if it is zero.
+	DedupeTTL time.Duration
+
+	callbacks map[RecordType]webhookCallback
+}
+
+// webhookCallback decodes a payload and passes it to a registered callback
+type webhookCallback func(ctx context.Context, data []byte) error
+
+// webhookPayloadError is returned by a webhookCallback when the payload cannot be decoded
+type webhookPayloadError struct {
+	err error
+}
+
+func (e *webhookPayloadError) Error() string {
+	return fmt.Sprintf("postmark: invalid webhook payload: %v", e.err)
+}
+
+var _ http.Handler = (*WebhookHandler)(nil)
+
+// NewWebhookHandler returns a WebhookHandler without any callbacks. The zero value of
+// WebhookHandler is equally usable.
+func NewWebhookHandler() *WebhookHandler {
+	return &WebhookHandler{}
+}
+
+// register sets the callback of a record type
+func (h *WebhookHandler) register(recordType RecordType, callback webhookCallback) *WebhookHandler {
+	if h.callbacks == nil {
+		h.callbacks = make(map[RecordType]webhookCallback)
+	}
+	h.callbacks[recordType] = callback
+	return h
+}
+
+// OnBounce registers the callback for bounce webhooks.
+func (h *WebhookHandler) OnBounce(fn func(ctx context.Context, w *BounceWebhook) error) *WebhookHandler {
+	return h.register(RecordTypeBounce, func(ctx context.Context, data []byte) error {
+		v := new(BounceWebhook)
+		if err := json.Unmarshal(data, v); err != nil {
+			return &webhookPayloadError{err}
+		}
+		return h.dedupe(ctx, v.dedupeKey(), func() error { return fn(ctx, v) })
+	})
+}
+
+// OnDelivery registers the callback for delivery webhooks.
+func (h *WebhookHandler) OnDelivery(fn func(ctx context.Context, w *DeliveryWebhook) error) *WebhookHandler {
+	return h.register(RecordTypeDelivery, func(ctx context.Context, data []byte) error {
+		v := new(DeliveryWebhook)
+		if err := json.Unmarshal(data, v); err != nil {
+			return &webhookPayloadError{err}
+		}
+		return h.dedupe(ctx, v.dedupeKey(), func() error { return fn(ctx, v) })
+	})
+}
+
+// OnOpen registers the callback for open webhooks.
+func (h *WebhookHandler) OnOpen(fn func(ctx context.Context, w *OpenWebhook) error) *WebhookHandler {
+	return h.register(RecordTypeOpen, func(ctx context.Context, data []byte) error {
+		v := new(OpenWebhook)
+		if err := json.Unmarshal(data, v); err != nil {
+			return &webhookPayloadError{err}
+		}
+		return h.dedupe(ctx, v.dedupeKey(), func() error { return fn(ctx, v) })
+	})
+}
+
+// OnClick registers the callback for click webhooks.
+func (h *WebhookHandler) OnClick(fn func(ctx context.Context, w *ClickWebhook) error) *WebhookHandler {
+	return h.register(RecordTypeClick, func(ctx context.Context, data []byte) error {
+		v := new(ClickWebhook)
+		if err := json.Unmarshal(data, v); err != nil {
+			return &webhookPayloadError{err}
+		}
+		return h.dedupe(ctx, v.dedupeKey(), func() error { return fn(ctx, v) })
+	})
+}
+
+// OnSpamComplaint registers the callback for spam complaint webhooks.
+func (h *WebhookHandler) OnSpamComplaint(fn func(ctx context.Context, w *SpamComplaintWebhook) error) *WebhookHandler {
+	return h.register(RecordTypeSpamComplaint, func(ctx context.Context, data []byte) error {
+		v := new(SpamComplaintWebhook)
+		if err := json.Unmarshal(data, v); err != nil {
+			return &webhookPayloadError{err}
+		}
+		return h.dedupe(ctx, v.dedupeKey(), func() error { return fn(ctx, v) })
+	})
+}
+
+// OnSubscriptionChange registers the callback for subscription change webhooks.
+func (h *WebhookHandler) OnSubscriptionChange(fn func(ctx context.Context, w *SubscriptionChangeWebhook) error) *WebhookHandler {
+	return h.register(RecordTypeSubscriptionChange, func(ctx context.Context, data []byte) error {
+		v := new(SubscriptionChangeWebhook)
+		if err := json.Unmarshal(data, v); err != nil {
+			return &webhookPayloadError{err}
+		}
+		return h.dedupe(ctx, v.dedupeKey(), func() error { return fn(ctx, v) })
+	})
+}
+
+// OnInbound registers the callback for inbound webhooks.
+func (h *WebhookHandler) OnInbound(fn func(ctx context.Context, w *InboundWebhook) error) *WebhookHandler {
+	return h.register(RecordTypeInbound, func(ctx context.Context, data []byte) error {
+		v := new(InboundWebhook)
+		if err := json.Unmarshal(data, v); err != nil {
+			return &webhookPayloadError{err}
+		}
+		return h.dedupe(ctx, v.dedupeKey(), func() error { return fn(ctx, v) })
+	})
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		h.fail(w, r, http.StatusMethodNotAllowed, fmt.Errorf("postmark: unexpected webhook method %s", r.Method))
+		return
+	}
+
+	maxBytes := h.MaxBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultWebhookMaxBodyBytes
+	}
+
+	// read one byte more than allowed to tell a body at the limit from one exceeding it
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBytes+1))
+	if err != nil {
+		h.fail(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if int64(len(data)) > maxBytes {
+		h.fail(w, r, http.StatusRequestEntityTooLarge, fmt.Errorf("postmark: webhook exceeds %d bytes", maxBytes))
+		return
+	}
+
+	var envelope struct{ RecordType RecordType }
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		h.fail(w, r, http.StatusBadRequest, &webhookPayloadError{err})
+		return
+	}
+
+	recordType := envelope.RecordType
+	if recordType == "" {
+		recordType = RecordTypeInbound
+	}
+
+	if callback, ok := h.callbacks[recordType]; ok {
+		if err := callback(r.Context(), data); err != nil {
+			if _, ok := err.(*webhookPayloadError); ok {
+				h.fail(w, r, http.StatusBadRequest, err)
+			} else {
+				h.fail(w, r, http.StatusInternalServerError, err)
+			}
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// fail responds to a webhook that is not acknowledged
+func (h *WebhookHandler) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.HandleError != nil {
+		h.HandleError(r, err)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
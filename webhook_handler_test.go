@@ -0,0 +1,156 @@
+package postmark
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func serveWebhook(h http.Handler, method, body string) int {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, "/webhook", strings.NewReader(body)))
+	return rec.Code
+}
+
+func TestWebhookHandlerDispatch(t *testing.T) {
+	var clicked, inbound int
+	h := NewWebhookHandler().
+		OnClick(func(_ context.Context, w *ClickWebhook) error {
+			if w.OriginalLink != "https://example.com" {
+				t.Errorf("unexpected click: %+v", w)
+			}
+			clicked++
+			return nil
+		}).
+		OnInbound(func(_ context.Context, w *InboundWebhook) error {
+			inbound++
+			return nil
+		})
+
+	if code := serveWebhook(h, "POST", clickExample); code != http.StatusOK {
+		t.Errorf("expected click to be acknowledged, got %d", code)
+	}
+	// inbound webhooks have no RecordType
+	if code := serveWebhook(h, "POST", inboundExample); code != http.StatusOK {
+		t.Errorf("expected inbound to be acknowledged, got %d", code)
+	}
+	// delivery webhooks have no callback, so they are acknowledged and dropped
+	if code := serveWebhook(h, "POST", deliveryExample); code != http.StatusOK {
+		t.Errorf("expected delivery to be acknowledged, got %d", code)
+	}
+
+	if clicked != 1 || inbound != 1 {
+		t.Errorf("expected one click and one inbound callback, got %d and %d", clicked, inbound)
+	}
+}
+
+func TestWebhookHandlerLiteral(t *testing.T) {
+	var delivered int
+	h := &WebhookHandler{MaxBodyBytes: 1 << 20}
+	h.OnDelivery(func(context.Context, *DeliveryWebhook) error {
+		delivered++
+		return nil
+	})
+
+	if code := serveWebhook(h, "POST", deliveryExample); code != http.StatusOK {
+		t.Errorf("expected delivery to be acknowledged, got %d", code)
+	}
+	if delivered != 1 {
+		t.Errorf("expected one delivery callback, got %d", delivered)
+	}
+}
+
+func TestWebhookHandlerFailures(t *testing.T) {
+	h := NewWebhookHandler().
+		OnBounce(func(context.Context, *BounceWebhook) error {
+			return errors.New("database unavailable")
+		})
+	h.MaxBodyBytes = 1024
+
+	for _, test := range []struct {
+		name   string
+		method string
+		body   string
+		status int
+	}{
+		{"callback error", "POST", `{"RecordType": "Bounce", "ID": 42}`, http.StatusInternalServerError},
+		{"invalid json", "POST", `{"RecordType": `, http.StatusBadRequest},
+		{"invalid payload", "POST", `{"RecordType": "Bounce", "ID": "42"}`, http.StatusBadRequest},
+		{"too large", "POST", `{"RecordType": "Bounce", "Details": "` + strings.Repeat("x", 1024) + `"}`, http.StatusRequestEntityTooLarge},
+		{"wrong method", "GET", "", http.StatusMethodNotAllowed},
+	} {
+		if code := serveWebhook(h, test.method, test.body); code != test.status {
+			t.Errorf("%s: expected status %d, got %d", test.name, test.status, code)
+		}
+	}
+}
+
+func TestWebhookHandlerDedupe(t *testing.T) {
+	var bounces int
+	fail := true
+	h := NewWebhookHandler().
+		OnBounce(func(context.Context, *BounceWebhook) error {
+			bounces++
+			if fail {
+				return errors.New("database unavailable")
+			}
+			return nil
+		})
+	h.Dedupe = NewMemoryDedupeStore()
+
+	const bounce = `{"RecordType": "Bounce", "ID": 42}`
+
+	// a failed event is processed again when it is retried
+	if code := serveWebhook(h, "POST", bounce); code != http.StatusInternalServerError {
+		t.Errorf("expected failed bounce to be retried, got %d", code)
+	}
+	fail = false
+	for i := 0; i < 2; i++ {
+		if code := serveWebhook(h, "POST", bounce); code != http.StatusOK {
+			t.Errorf("expected bounce to be acknowledged, got %d", code)
+		}
+	}
+
+	if bounces != 2 {
+		t.Errorf("expected the bounce to be processed twice, got %d", bounces)
+	}
+}
+
+func TestMemoryDedupeStoreExpiry(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewMemoryDedupeStore()
+	s.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	if fresh, _ := s.Claim(ctx, "key", time.Hour); !fresh {
+		t.Errorf("expected first claim to succeed")
+	}
+	now = now.Add(59 * time.Minute)
+	if fresh, _ := s.Claim(ctx, "key", time.Hour); fresh {
+		t.Errorf("expected claim within ttl to fail")
+	}
+	now = now.Add(time.Minute)
+	if fresh, _ := s.Claim(ctx, "key", time.Hour); !fresh {
+		t.Errorf("expected claim after ttl to succeed")
+	}
+}
+
+func TestMemoryDedupeStoreZeroValue(t *testing.T) {
+	var s MemoryDedupeStore
+	ctx := context.Background()
+
+	if err := s.Release(ctx, "key"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if fresh, err := s.Claim(ctx, "key", time.Hour); err != nil || !fresh {
+		t.Errorf("expected first claim to succeed, got %v, %v", fresh, err)
+	}
+	if fresh, _ := s.Claim(ctx, "key", time.Hour); fresh {
+		t.Errorf("expected second claim to fail")
+	}
+}
@@ -4,21 +4,41 @@ import (
 	"time"
 )
 
+// RecordType defines the kinds of events Postmark sends webhooks for. Every webhook payload
+// carries its RecordType, which allows an endpoint receiving several kinds to tell them apart.
+type RecordType string
+
+// RecordType constant definitions. Inbound webhooks are sent without a RecordType, so
+// RecordTypeInbound never appears in a payload.
+const (
+	RecordTypeBounce             RecordType = "Bounce"
+	RecordTypeDelivery           RecordType = "Delivery"
+	RecordTypeOpen               RecordType = "Open"
+	RecordTypeClick              RecordType = "Click"
+	RecordTypeSpamComplaint      RecordType = "SpamComplaint"
+	RecordTypeSubscriptionChange RecordType = "SubscriptionChange"
+	RecordTypeInbound            RecordType = "Inbound"
+)
+
 // BounceWebhook defines the format of a webhook sent after an email bounced
 // http://developer.postmarkapp.com/developer-bounce-webhook.html#data
 type BounceWebhook struct {
+	RecordType RecordType
 	// you can use the ID to make different requests to the Bounce API.
 	ID int64
 	// the classification that Postmark assigned the bounce.
-	Type        string
-	TypeCode    int64
-	Name        string
-	Tag         string
-	MessageID   string
-	Description string
-	Details     string
+	Type          string
+	TypeCode      int64
+	Name          string
+	Tag           string
+	MessageID     string
+	ServerID      int64
+	MessageStream string
+	Description   string
+	Details       string
 	// the email address that bounced.
 	Email         string
+	From          string
 	BouncedAt     time.Time
 	DumpAvailable bool
 	// lets you know if this bounce caused the email address to be deactivated.
@@ -26,11 +46,41 @@ type BounceWebhook struct {
 	// lets you know if this address can be activated again.
 	CanActivate bool
 	Subject     string
+	// the full content of the bounce, only sent if enabled in the server settings.
+	Content  string
+	Metadata map[string]string
+}
+
+// Bounce converts the webhook to the Bounce returned by the Bounce API, so that bounces from both
+// sources can be handled by the same code.
+func (w *BounceWebhook) Bounce() *Bounce {
+	return &Bounce{
+		ID:            w.ID,
+		Type:          w.Type,
+		TypeCode:      w.TypeCode,
+		Name:          w.Name,
+		Tag:           w.Tag,
+		MessageID:     w.MessageID,
+		ServerID:      w.ServerID,
+		MessageStream: w.MessageStream,
+		Description:   w.Description,
+		Details:       w.Details,
+		Email:         w.Email,
+		From:          w.From,
+		BouncedAt:     w.BouncedAt,
+		DumpAvailable: w.DumpAvailable,
+		Inactive:      w.Inactive,
+		CanActivate:   w.CanActivate,
+		Subject:       w.Subject,
+		Content:       w.Content,
+		Metadata:      w.Metadata,
+	}
 }
 
 // InboundWebhook defines the format of a webhook sent in response to an inbound email.
 // http://developer.postmarkapp.com/developer-inbound-webhook.html#data
 type InboundWebhook struct {
+	RecordType        RecordType
 	FromName          string
 	From              string
 	FromFull          InboundEntity
@@ -78,6 +128,7 @@ type InboundAttachment struct {
 // OpenWebhook defines the format of a webhook sent in response to the opening of an email.
 // http://developer.postmarkapp.com/developer-open-webhook.html#data
 type OpenWebhook struct {
+	RecordType  RecordType
 	FirstOpen   bool
 	Client      OpenContext
 	OS          OpenContext
@@ -89,6 +140,25 @@ type OpenWebhook struct {
 	ReceivedAt  Time
 	Tag         string
 	Recipient   string
+	Metadata    map[string]string
+}
+
+// Open converts the webhook to the Open returned by the Messages API.
+func (w *OpenWebhook) Open() *Open {
+	return &Open{
+		FirstOpen:   w.FirstOpen,
+		Client:      w.Client,
+		OS:          w.OS,
+		Platform:    w.Platform,
+		UserAgent:   w.UserAgent,
+		ReadSeconds: w.ReadSeconds,
+		Geo:         w.Geo,
+		MessageID:   w.MessageID,
+		ReceivedAt:  w.ReceivedAt,
+		Tag:         w.Tag,
+		Recipient:   w.Recipient,
+		Metadata:    w.Metadata,
+	}
 }
 
 // OpenContext defines the context within which an open occurred.
@@ -102,9 +172,94 @@ type OpenContext struct {
 type OpenGeolocation struct {
 	CountryISOCode string
 	Country        string
+	RegionISOCode  string
 	Region         string
 	City           string
 	Zip            string
 	Coords         string
 	IP             string
 }
+
+// DeliveryWebhook defines the format of a webhook sent after an email was accepted by the
+// recipient's mail server.
+// http://developer.postmarkapp.com/developer-delivery-webhook.html#data
+type DeliveryWebhook struct {
+	RecordType    RecordType
+	ServerID      int64
+	MessageStream string
+	MessageID     string
+	Recipient     string
+	Tag           string
+	DeliveredAt   time.Time
+	Details       string
+	Metadata      map[string]string
+}
+
+// ClickWebhook defines the format of a webhook sent after a tracked link was clicked.
+// http://developer.postmarkapp.com/developer-click-webhook.html#data
+type ClickWebhook struct {
+	RecordType    RecordType
+	MessageStream string
+	// where the link was in the message, either "HTML" or "Text"
+	ClickLocation string
+	Client        OpenContext
+	OS            OpenContext
+	Platform      string
+	UserAgent     string
+	OriginalLink  string
+	Geo           OpenGeolocation
+	MessageID     string
+	ReceivedAt    Time
+	Tag           string
+	Recipient     string
+	Metadata      map[string]string
+}
+
+// Click converts the webhook to the Click returned by the Messages API.
+func (w *ClickWebhook) Click() *Click {
+	return &Click{
+		ClickLocation: w.ClickLocation,
+		Client:        w.Client,
+		OS:            w.OS,
+		Platform:      w.Platform,
+		UserAgent:     w.UserAgent,
+		OriginalLink:  w.OriginalLink,
+		Geo:           w.Geo,
+		MessageID:     w.MessageID,
+		ReceivedAt:    w.ReceivedAt,
+		Tag:           w.Tag,
+		Recipient:     w.Recipient,
+		Metadata:      w.Metadata,
+	}
+}
+
+// SpamComplaintWebhook defines the format of a webhook sent after a recipient marked an email as
+// spam. Postmark models spam complaints as a type of bounce, so its fields are those of a
+// BounceWebhook.
+// http://developer.postmarkapp.com/developer-spam-complaint-webhook.html#data
+type SpamComplaintWebhook BounceWebhook
+
+// Bounce converts the webhook to the Bounce returned by the Bounce API.
+func (w *SpamComplaintWebhook) Bounce() *Bounce {
+	return (*BounceWebhook)(w).Bounce()
+}
+
+// SubscriptionChangeWebhook defines the format of a webhook sent after an address was suppressed
+// or reactivated for a message stream.
+// http://developer.postmarkapp.com/developer-subscription-change-webhook.html#data
+type SubscriptionChangeWebhook struct {
+	RecordType    RecordType
+	ServerID      int64
+	MessageStream string
+	MessageID     string
+	ChangedAt     time.Time
+	Recipient     string
+	// one of "Recipient", "Customer" or "Admin"
+	Origin string
+	// whether the address is now suppressed, false if it was reactivated
+	SuppressSending bool
+	// one of "HardBounce", "SpamComplaint" or "ManualSuppression", empty if reactivated
+	SuppressionReason string
+	Tag               string
+	Metadata          map[string]string
+}
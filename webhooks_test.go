@@ -30,6 +30,9 @@ func TestBounceWebhookUnmarshal(t *testing.T) {
 	if err := json.Unmarshal([]byte(bounceExample), &v); err != nil {
 		t.Errorf("error unmarshalling json: %v", err)
 	}
+	if b := v.Bounce(); b.ID != 42 || b.Email != "john@example.com" || b.BouncedAt != v.BouncedAt {
+		t.Errorf("unexpected bounce: %+v", b)
+	}
 }
 
 // from: http://developer.postmarkapp.com/developer-inbound-webhook.html
@@ -161,4 +164,169 @@ func TestOpenWebhookUnmarshal(t *testing.T) {
 	if err := json.Unmarshal([]byte(openExample), &v); err != nil {
 		t.Errorf("error unmarshalling json: %v", err)
 	}
+	if o := v.Open(); o.Recipient != "john@example.com" || o.Geo.IP != "188.2.95.4" {
+		t.Errorf("unexpected open: %+v", o)
+	}
+}
+
+// from: http://developer.postmarkapp.com/developer-delivery-webhook.html
+const deliveryExample = `
+{
+  "RecordType": "Delivery",
+  "ServerID": 23,
+  "MessageStream": "outbound",
+  "MessageID": "00000000-0000-0000-0000-000000000000",
+  "Recipient": "john@example.com",
+  "Tag": "welcome-email",
+  "DeliveredAt": "2021-02-21T16:34:52Z",
+  "Details": "Test delivery webhook details",
+  "Metadata": {
+    "example": "value",
+    "example_2": "value"
+  }
+}
+`
+
+func TestDeliveryWebhookUnmarshal(t *testing.T) {
+	var v DeliveryWebhook
+	if err := json.Unmarshal([]byte(deliveryExample), &v); err != nil {
+		t.Fatalf("error unmarshalling json: %v", err)
+	}
+	if v.RecordType != RecordTypeDelivery {
+		t.Errorf("unexpected record type: %q", v.RecordType)
+	}
+	if v.Recipient != "john@example.com" || v.Metadata["example_2"] != "value" {
+		t.Errorf("unexpected webhook: %+v", v)
+	}
+}
+
+// from: http://developer.postmarkapp.com/developer-click-webhook.html
+const clickExample = `
+{
+  "RecordType": "Click",
+  "MessageStream": "outbound",
+  "Metadata": {
+    "a_key": "a_value",
+    "b_key": "b_value"
+  },
+  "Recipient": "john@example.com",
+  "MessageID": "00000000-0000-0000-0000-000000000000",
+  "ReceivedAt": "2019-11-05T16:33:54.9070259Z",
+  "Platform": "Desktop",
+  "ClickLocation": "HTML",
+  "OriginalLink": "https://example.com",
+  "Tag": "welcome-email",
+  "UserAgent": "Mozilla\/5.0 (Macintosh; Intel Mac OS X 10_7_5) AppleWebKit\/537.36 (KHTML, like Gecko) Chrome\/35.0.1916.153 Safari\/537.36",
+  "OS": {
+    "Name": "OS X 10.7 Lion",
+    "Family": "OS X 10",
+    "Company": "Apple Computer, Inc."
+  },
+  "Client": {
+    "Name": "Chrome 35.0.1916.153",
+    "Family": "Chrome",
+    "Company": "Google"
+  },
+  "Geo": {
+    "IP": "188.2.95.4",
+    "City": "Novi Sad",
+    "Country": "Serbia",
+    "CountryISOCode": "RS",
+    "Region": "Autonomna Pokrajina Vojvodina",
+    "RegionISOCode": "VO",
+    "Zip": "21000",
+    "Coords": "45.2517,19.8369"
+  }
+}
+`
+
+func TestClickWebhookUnmarshal(t *testing.T) {
+	var v ClickWebhook
+	if err := json.Unmarshal([]byte(clickExample), &v); err != nil {
+		t.Fatalf("error unmarshalling json: %v", err)
+	}
+	if v.RecordType != RecordTypeClick {
+		t.Errorf("unexpected record type: %q", v.RecordType)
+	}
+	if v.OriginalLink != "https://example.com" || v.Client.Family != "Chrome" || v.Geo.RegionISOCode != "VO" {
+		t.Errorf("unexpected webhook: %+v", v)
+	}
+}
+
+// from: http://developer.postmarkapp.com/developer-spam-complaint-webhook.html
+const spamComplaintExample = `
+{
+  "RecordType": "SpamComplaint",
+  "MessageStream": "outbound",
+  "ID": 42,
+  "Type": "SpamComplaint",
+  "TypeCode": 100001,
+  "Name": "Spam complaint",
+  "Tag": "Test",
+  "MessageID": "00000000-0000-0000-0000-000000000000",
+  "Metadata": {
+    "a_key": "a_value",
+    "b_key": "b_value"
+  },
+  "ServerID": 1234,
+  "Description": "",
+  "Details": "Test spam complaint details",
+  "Email": "john@example.com",
+  "From": "sender@example.com",
+  "BouncedAt": "2019-11-05T16:33:54.9070259Z",
+  "DumpAvailable": true,
+  "Inactive": true,
+  "CanActivate": false,
+  "Subject": "Test subject",
+  "Content": "<Abuse report dump>"
+}
+`
+
+func TestSpamComplaintWebhookUnmarshal(t *testing.T) {
+	var v SpamComplaintWebhook
+	if err := json.Unmarshal([]byte(spamComplaintExample), &v); err != nil {
+		t.Fatalf("error unmarshalling json: %v", err)
+	}
+	if v.RecordType != RecordTypeSpamComplaint {
+		t.Errorf("unexpected record type: %q", v.RecordType)
+	}
+	if v.ID != 42 || v.TypeCode != 100001 || v.MessageStream != "outbound" || !v.Inactive {
+		t.Errorf("unexpected webhook: %+v", v)
+	}
+	if b := v.Bounce(); b.Content != "<Abuse report dump>" {
+		t.Errorf("unexpected bounce: %+v", b)
+	}
+}
+
+// from: http://developer.postmarkapp.com/developer-subscription-change-webhook.html
+const subscriptionChangeExample = `
+{
+  "RecordType": "SubscriptionChange",
+  "MessageID": "00000000-0000-0000-0000-000000000000",
+  "ServerID": 23,
+  "MessageStream": "bulk",
+  "ChangedAt": "2020-02-01T10:53:34.416071Z",
+  "Recipient": "bounced-address@wildbit.com",
+  "Origin": "Recipient",
+  "SuppressSending": true,
+  "SuppressionReason": "HardBounce",
+  "Tag": "my-tag",
+  "Metadata": {
+    "example": "value",
+    "example_2": "value"
+  }
+}
+`
+
+func TestSubscriptionChangeWebhookUnmarshal(t *testing.T) {
+	var v SubscriptionChangeWebhook
+	if err := json.Unmarshal([]byte(subscriptionChangeExample), &v); err != nil {
+		t.Fatalf("error unmarshalling json: %v", err)
+	}
+	if v.RecordType != RecordTypeSubscriptionChange {
+		t.Errorf("unexpected record type: %q", v.RecordType)
+	}
+	if !v.SuppressSending || v.SuppressionReason != "HardBounce" || v.MessageStream != "bulk" {
+		t.Errorf("unexpected webhook: %+v", v)
+	}
 }